@@ -0,0 +1,149 @@
+// Command fornex-tfgen writes Terraform configuration for the domains and
+// records of an existing Fornex account, together with the import blocks
+// that bring them under management.
+//
+// Usage:
+//
+//	fornex-tfgen [flags] > fornex.tf
+//	terraform plan
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+	"github.com/mglants/terraform-provider-fornex/internal/credentials"
+	"github.com/mglants/terraform-provider-fornex/internal/tfgen"
+)
+
+var (
+	version string = "dev"
+)
+
+// listFlag collects a flag that may be repeated or given as a comma
+// separated list.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+type config struct {
+	apiKey    string
+	profile   string
+	baseURL   string
+	domains   listFlag
+	nameRegex string
+	tags      listFlag
+	types     listFlag
+	forEach   bool
+	out       string
+}
+
+func main() {
+	var cfg config
+
+	flag.StringVar(&cfg.apiKey, "api-key", "", "Fornex API key (default: FORNEX_API_KEY or the shared credentials file)")
+	flag.StringVar(&cfg.profile, "profile", "", "profile in the shared credentials file (default: FORNEX_PROFILE or \"default\")")
+	flag.StringVar(&cfg.baseURL, "base-url", os.Getenv("FORNEX_BASE_URL"), "Fornex API base URL")
+	flag.Var(&cfg.domains, "domain", "only include this domain; may be repeated or comma separated")
+	flag.StringVar(&cfg.nameRegex, "name-regex", "", "only include domains whose name matches this regular expression")
+	flag.Var(&cfg.tags, "tag", "only include domains with this tag; may be repeated or comma separated")
+	flag.Var(&cfg.types, "type", "only include records of this type, e.g. A,AAAA,MX; may be repeated")
+	flag.BoolVar(&cfg.forEach, "for-each", false, "emit one fornex_record resource per domain with a for_each map")
+	flag.StringVar(&cfg.out, "out", "", "write the configuration to this file instead of standard output")
+	flag.Parse()
+
+	if err := run(context.Background(), cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "fornex-tfgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg config) error {
+	var nameRegex *regexp.Regexp
+	if cfg.nameRegex != "" {
+		re, err := regexp.Compile(cfg.nameRegex)
+		if err != nil {
+			return fmt.Errorf("invalid -name-regex: %w", err)
+		}
+		nameRegex = re
+	}
+
+	apiKey, _, err := credentials.Resolve(ctx, credentials.Config{APIKey: cfg.apiKey, Profile: cfg.profile})
+	if errors.Is(err, credentials.ErrNotFound) {
+		return errors.New("no API key: pass -api-key, set FORNEX_API_KEY or add it to the shared credentials file")
+	} else if err != nil {
+		return err
+	}
+
+	c := client.NewClient(apiKey, cfg.baseURL)
+	c.UserAgent = client.UserAgent(version, "", "fornex-tfgen")
+	// The generator never changes anything.
+	c.ReadOnly = true
+
+	domains, err := c.ListDomains(ctx)
+	if err != nil {
+		return fmt.Errorf("listing domains: %w", err)
+	}
+
+	var zones []tfgen.Zone
+	for _, domain := range domains {
+		if len(cfg.domains) > 0 && !slices.Contains(cfg.domains, domain.Name) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(domain.Name) {
+			continue
+		}
+		if !domain.HasTags(cfg.tags) {
+			continue
+		}
+
+		entries, err := c.ListEntries(ctx, domain.Name)
+		if err != nil {
+			return fmt.Errorf("listing records of %s: %w", domain.Name, err)
+		}
+		if len(cfg.types) > 0 {
+			entries = slices.DeleteFunc(entries, func(e client.Entry) bool {
+				return !slices.ContainsFunc(cfg.types, func(t string) bool { return strings.EqualFold(t, e.Type) })
+			})
+		}
+
+		zones = append(zones, tfgen.Zone{Domain: domain, Entries: entries})
+	}
+
+	if len(zones) == 0 {
+		return errors.New("no domains match the filters")
+	}
+
+	opts := tfgen.Options{ForEach: cfg.forEach, Skipped: os.Stderr}
+	if cfg.out == "" {
+		return tfgen.Generate(os.Stdout, zones, opts)
+	}
+
+	f, err := os.Create(cfg.out)
+	if err != nil {
+		return err
+	}
+	if err := tfgen.Generate(f, zones, opts); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
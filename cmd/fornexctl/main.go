@@ -0,0 +1,188 @@
+// Command fornexctl inspects and changes Fornex DNS zones from the command
+// line, without Terraform.
+//
+// Usage:
+//
+//	fornexctl [global flags] <command> [arguments]
+//
+// Commands:
+//
+//	domains                              list domains
+//	records list <domain>                list the records of a domain
+//	records add <domain> -host -type -value [-ttl] [-priority]
+//	records update <domain> <id> [-host] [-type] [-value] [-ttl] [-priority]
+//	records delete <domain> <id>
+//	zone export <domain> [-out file]     write the zone as a zone file
+//	zone import <domain> <file>          apply a zone file to the live zone
+//	diff <domain> <file>                 compare a zone file with the live zone
+//
+// Global flags select the credentials, the API endpoint and the output
+// format, table (default) or json.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+	"github.com/mglants/terraform-provider-fornex/internal/credentials"
+)
+
+var (
+	version string = "dev"
+)
+
+// Output formats.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// errUsage is returned for invalid command lines; the usage has already been
+// printed.
+var errUsage = errors.New("invalid usage")
+
+const usage = `Usage: fornexctl [global flags] <command> [arguments]
+
+Commands:
+  domains                                   list domains
+  records list <domain> [-host] [-type]     list the records of a domain
+  records add <domain> -host -type -value [-ttl] [-priority]
+  records update <domain> <id> [-host] [-type] [-value] [-ttl] [-priority]
+  records delete <domain> <id>
+  zone export <domain> [-out file]          write the zone as a zone file
+  zone import <domain> <file> [-prune] [-dry-run]
+  diff <domain> <file>                      compare a zone file with the live zone
+
+Global flags:
+`
+
+type app struct {
+	client *client.Client
+	output string
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func main() {
+	err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, errUsage) {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fornexctl: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("fornexctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	apiKey := fs.String("api-key", "", "Fornex API key (default: FORNEX_API_KEY or the shared credentials file)")
+	profile := fs.String("profile", "", "profile in the shared credentials file (default: FORNEX_PROFILE or \"default\")")
+	baseURL := fs.String("base-url", os.Getenv("FORNEX_BASE_URL"), "Fornex API base URL")
+	output := fs.String("output", outputTable, "output format: table or json")
+
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if *output != outputTable && *output != outputJSON {
+		fmt.Fprintf(stderr, "unknown output format %q\n", *output)
+		return errUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	key, _, err := credentials.Resolve(ctx, credentials.Config{APIKey: *apiKey, Profile: *profile})
+	if errors.Is(err, credentials.ErrNotFound) {
+		return errors.New("no API key: pass -api-key, set FORNEX_API_KEY or add it to the shared credentials file")
+	} else if err != nil {
+		return err
+	}
+
+	c := client.NewClient(key, *baseURL)
+	c.UserAgent = client.UserAgent(version, "", "fornexctl")
+
+	a := &app{client: c, output: *output, stdout: stdout, stderr: stderr}
+
+	command, rest := fs.Arg(0), fs.Args()[1:]
+	switch command {
+	case "domains":
+		return a.domains(ctx, rest)
+	case "records":
+		return a.records(ctx, rest)
+	case "zone":
+		return a.zone(ctx, rest)
+	case "diff":
+		return a.diff(ctx, rest)
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n", command)
+		fs.Usage()
+		return errUsage
+	}
+}
+
+// parse parses flags that may appear before, between or after the positional
+// arguments, and checks the number of positional arguments.
+func (a *app) parse(fs *flag.FlagSet, args []string, positional ...string) ([]string, error) {
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: fornexctl %s", fs.Name())
+		for _, p := range positional {
+			fmt.Fprintf(a.stderr, " <%s>", p)
+		}
+		fmt.Fprintln(a.stderr, " [flags]")
+		fs.PrintDefaults()
+	}
+
+	var values []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, errUsage
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		values = append(values, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(values) != len(positional) {
+		fs.Usage()
+		return nil, errUsage
+	}
+	return values, nil
+}
+
+// intFlag is an integer flag that records whether it was set.
+type intFlag struct {
+	value *int
+}
+
+func (f *intFlag) String() string {
+	if f.value == nil {
+		return ""
+	}
+	return strconv.Itoa(*f.value)
+}
+
+func (f *intFlag) Set(s string) error {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	f.value = &v
+	return nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeAPI serves a fixed zone and records the modifying requests it receives.
+func fakeAPI(t *testing.T) (*httptest.Server, *[]string) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/entry_set/"):
+			_, _ = w.Write([]byte(`[
+				{"id": 1, "host": "www", "type": "A", "value": "192.0.2.1", "ttl": 300},
+				{"id": 2, "host": "@", "type": "NS", "value": "ns1.fornex.com"},
+				{"id": 3, "host": "old", "type": "A", "value": "192.0.2.2", "ttl": 300}
+			]`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"id": 1, "host": "www", "type": "A", "value": "192.0.2.1", "ttl": 300}`))
+		default:
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+			if strings.Contains(string(body), `"host":"rejected"`) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"detail":"rejected"}`))
+				return
+			}
+			_, _ = w.Write(body)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func runCommand(t *testing.T, server *httptest.Server, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"-api-key", "test-key", "-base-url", server.URL}, args...)
+	err := run(context.Background(), args, &stdout, &stderr)
+	return stdout.String(), err
+}
+
+func TestRecordsUpdate(t *testing.T) {
+	server, requests := fakeAPI(t)
+
+	if _, err := runCommand(t, server, "records", "update", "example.com", "1", "-ttl", "600"); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	// Only the TTL changes; the other fields come from the live record.
+	expected := []string{`PUT /dns/domain/example.com/entry_set/1/ {"id":1,"host":"www","type":"A","ttl":600,"value":"192.0.2.1"}`}
+	if !slices.Equal(*requests, expected) {
+		t.Errorf("Expected requests %q, got: %q", expected, *requests)
+	}
+}
+
+func TestZoneImport(t *testing.T) {
+	server, requests := fakeAPI(t)
+
+	file := filepath.Join(t.TempDir(), "example.com.zone")
+	zone := "$ORIGIN example.com.\nwww 600 IN A 192.0.2.1\nnew 300 IN A 192.0.2.3\n"
+	if err := os.WriteFile(file, []byte(zone), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCommand(t, server, "diff", "example.com", file)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	for _, line := range []string{"+ new", "- @", "- old", "~ www"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected diff to contain %q, got:\n%s", line, out)
+		}
+	}
+	if len(*requests) != 0 {
+		t.Errorf("Expected diff not to modify the zone, got: %q", *requests)
+	}
+
+	if _, err := runCommand(t, server, "zone", "import", "example.com", file, "-prune"); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	// Deletes come first, then updates and adds. The apex NS record is kept
+	// even with -prune.
+	expected := []string{
+		`DELETE /dns/domain/example.com/entry_set/3/ `,
+		`PUT /dns/domain/example.com/entry_set/1/ {"id":1,"host":"www","type":"A","ttl":600,"value":"192.0.2.1"}`,
+		`POST /dns/domain/example.com/entry_set/ {"host":"new","type":"A","ttl":300,"value":"192.0.2.3"}`,
+	}
+	if !slices.Equal(*requests, expected) {
+		t.Errorf("Expected requests %q, got: %q", expected, *requests)
+	}
+}
+
+func TestZoneImportPartialFailure(t *testing.T) {
+	server, requests := fakeAPI(t)
+
+	file := filepath.Join(t.TempDir(), "example.com.zone")
+	zone := "$ORIGIN example.com.\nwww 600 IN A 192.0.2.1\nrejected 300 IN A 192.0.2.3\n"
+	if err := os.WriteFile(file, []byte(zone), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := runCommand(t, server, "zone", "import", "example.com", file, "-prune")
+	if err == nil || !strings.Contains(err.Error(), "2 of 3 changes applied") {
+		t.Fatalf("Expected partial failure error, got: %v", err)
+	}
+
+	// The changes made before the failure are reported.
+	for _, line := range []string{"- old", "~ www"} {
+		if !strings.Contains(out, line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, "rejected") {
+		t.Errorf("Expected the failed change not to be reported as applied, got:\n%s", out)
+	}
+	if len(*requests) != 3 {
+		t.Errorf("Expected 3 requests, got: %q", *requests)
+	}
+}
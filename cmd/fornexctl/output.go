@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+	"github.com/mglants/terraform-provider-fornex/internal/zonefile"
+)
+
+func (a *app) writeJSON(v any) error {
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeTable writes rows as aligned columns below a header.
+func (a *app) writeTable(header []string, rows [][]string) error {
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (a *app) writeDomains(domains []client.Domain) error {
+	if a.output == outputJSON {
+		return a.writeJSON(domains)
+	}
+
+	rows := make([][]string, len(domains))
+	for i, d := range domains {
+		rows[i] = []string{d.Name, d.Created, strings.Join(d.Tags, ",")}
+	}
+	return a.writeTable([]string{"NAME", "CREATED", "TAGS"}, rows)
+}
+
+func (a *app) writeEntries(entries []client.Entry) error {
+	if a.output == outputJSON {
+		if entries == nil {
+			entries = []client.Entry{}
+		}
+		return a.writeJSON(entries)
+	}
+
+	rows := make([][]string, len(entries))
+	for i, e := range entries {
+		rows[i] = []string{strconv.Itoa(e.ID), e.Host, e.Type, optional(e.TTL), optional(e.Priority), e.Value}
+	}
+	return a.writeTable([]string{"ID", "HOST", "TYPE", "TTL", "PRIORITY", "VALUE"}, rows)
+}
+
+// writeChanges writes zone changes, in table mode as a diff with one line per
+// change: "+" additions, "-" deletions and "~" TTL updates.
+func (a *app) writeChanges(changes []zonefile.Change) error {
+	if a.output == outputJSON {
+		if changes == nil {
+			changes = []zonefile.Change{}
+		}
+		return a.writeJSON(changes)
+	}
+
+	tw := tabwriter.NewWriter(a.stdout, 0, 4, 1, ' ', 0)
+	for _, c := range changes {
+		switch c.Op {
+		case zonefile.OpAdd:
+			fmt.Fprintf(tw, "+\t%s\t%s\t%s\t%s\n", c.Desired.Host, optional(c.Desired.TTL), c.Desired.Type, describeValue(*c.Desired))
+		case zonefile.OpDelete:
+			fmt.Fprintf(tw, "-\t%s\t%s\t%s\t%s\n", c.Live.Host, optional(c.Live.TTL), c.Live.Type, describeValue(*c.Live))
+		case zonefile.OpUpdate:
+			fmt.Fprintf(tw, "~\t%s\t%s -> %s\t%s\t%s\n", c.Live.Host, optional(c.Live.TTL), optional(c.Desired.TTL), c.Live.Type, describeValue(*c.Live))
+		}
+	}
+	return tw.Flush()
+}
+
+func describeValue(e client.Entry) string {
+	if e.Priority != nil {
+		return strconv.Itoa(*e.Priority) + " " + e.Value
+	}
+	return e.Value
+}
+
+func optional(v *int) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Itoa(*v)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+func (a *app) domains(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("domains", flag.ContinueOnError)
+	if _, err := a.parse(fs, args); err != nil {
+		return err
+	}
+
+	domains, err := a.client.ListDomains(ctx)
+	if err != nil {
+		return fmt.Errorf("listing domains: %w", err)
+	}
+	slices.SortFunc(domains, func(x, y client.Domain) int { return strings.Compare(x.Name, y.Name) })
+	return a.writeDomains(domains)
+}
+
+func (a *app) records(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(a.stderr, "Usage: fornexctl records list|add|update|delete ...")
+		return errUsage
+	}
+
+	switch args[0] {
+	case "list":
+		return a.listRecords(ctx, args[1:])
+	case "add":
+		return a.addRecord(ctx, args[1:])
+	case "update":
+		return a.updateRecord(ctx, args[1:])
+	case "delete":
+		return a.deleteRecord(ctx, args[1:])
+	default:
+		fmt.Fprintf(a.stderr, "unknown records command %q\n", args[0])
+		return errUsage
+	}
+}
+
+func (a *app) listRecords(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("records list", flag.ContinueOnError)
+	host := fs.String("host", "", "only list records of this host, @ for the apex")
+	typ := fs.String("type", "", "only list records of this type")
+
+	values, err := a.parse(fs, args, "domain")
+	if err != nil {
+		return err
+	}
+	domain := values[0]
+
+	entries, err := a.client.ListEntries(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("listing records of %s: %w", domain, err)
+	}
+
+	entries = slices.DeleteFunc(entries, func(e client.Entry) bool {
+		return (*host != "" && client.CanonicalHost(e.Host, domain) != client.CanonicalHost(*host, domain)) ||
+			(*typ != "" && !strings.EqualFold(e.Type, *typ))
+	})
+	return a.writeEntries(entries)
+}
+
+// recordFlags registers the flags describing a record.
+func recordFlags(fs *flag.FlagSet) (host, typ, value *string, ttl, priority *intFlag) {
+	host = fs.String("host", "", "host part of the record, @ for the apex")
+	typ = fs.String("type", "", "record type: A, AAAA, CAA, CNAME, MX, NS, SRV or TXT")
+	value = fs.String("value", "", "record value")
+	ttl, priority = &intFlag{}, &intFlag{}
+	fs.Var(ttl, "ttl", "time to live in seconds")
+	fs.Var(priority, "priority", "priority of MX and SRV records")
+	return host, typ, value, ttl, priority
+}
+
+func (a *app) addRecord(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("records add", flag.ContinueOnError)
+	host, typ, value, ttl, priority := recordFlags(fs)
+
+	values, err := a.parse(fs, args, "domain")
+	if err != nil {
+		return err
+	}
+	if *host == "" || *typ == "" || *value == "" {
+		fmt.Fprintln(a.stderr, "-host, -type and -value are required")
+		return errUsage
+	}
+
+	entry, err := a.client.CreateEntry(ctx, values[0], client.Entry{
+		Host:     *host,
+		Type:     strings.ToUpper(*typ),
+		Value:    *value,
+		TTL:      ttl.value,
+		Priority: priority.value,
+	})
+	if err != nil {
+		return fmt.Errorf("adding record: %w", err)
+	}
+	return a.writeEntries([]client.Entry{*entry})
+}
+
+func (a *app) updateRecord(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("records update", flag.ContinueOnError)
+	host, typ, value, ttl, priority := recordFlags(fs)
+
+	values, err := a.parse(fs, args, "domain", "id")
+	if err != nil {
+		return err
+	}
+	domain := values[0]
+	id, err := strconv.Atoi(values[1])
+	if err != nil {
+		return fmt.Errorf("invalid record ID %q", values[1])
+	}
+
+	entry, err := a.client.GetEntry(ctx, domain, id)
+	if err != nil {
+		return fmt.Errorf("reading record %d: %w", id, err)
+	}
+
+	// Only the given flags change the record.
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "host":
+			entry.Host = *host
+		case "type":
+			entry.Type = strings.ToUpper(*typ)
+		case "value":
+			entry.Value = *value
+		case "ttl":
+			entry.TTL = ttl.value
+		case "priority":
+			entry.Priority = priority.value
+		}
+	})
+
+	updated, err := a.client.UpdateEntry(ctx, domain, id, *entry)
+	if err != nil {
+		return fmt.Errorf("updating record %d: %w", id, err)
+	}
+	return a.writeEntries([]client.Entry{*updated})
+}
+
+func (a *app) deleteRecord(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("records delete", flag.ContinueOnError)
+
+	values, err := a.parse(fs, args, "domain", "id")
+	if err != nil {
+		return err
+	}
+	id, err := strconv.Atoi(values[1])
+	if err != nil {
+		return fmt.Errorf("invalid record ID %q", values[1])
+	}
+
+	if err := a.client.DeleteEntry(ctx, values[0], id); err != nil {
+		return fmt.Errorf("deleting record %d: %w", id, err)
+	}
+	if a.output == outputJSON {
+		return a.writeJSON(map[string]any{"deleted": id})
+	}
+	_, err = fmt.Fprintf(a.stdout, "Deleted record %d\n", id)
+	return err
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+	"github.com/mglants/terraform-provider-fornex/internal/zonefile"
+)
+
+func (a *app) zone(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(a.stderr, "Usage: fornexctl zone export|import ...")
+		return errUsage
+	}
+
+	switch args[0] {
+	case "export":
+		return a.exportZone(ctx, args[1:])
+	case "import":
+		return a.importZone(ctx, args[1:])
+	default:
+		fmt.Fprintf(a.stderr, "unknown zone command %q\n", args[0])
+		return errUsage
+	}
+}
+
+func (a *app) exportZone(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("zone export", flag.ContinueOnError)
+	out := fs.String("out", "", "write the zone file to this file instead of standard output")
+
+	values, err := a.parse(fs, args, "domain")
+	if err != nil {
+		return err
+	}
+	domain := values[0]
+
+	entries, err := a.client.ListEntries(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("listing records of %s: %w", domain, err)
+	}
+
+	if *out == "" {
+		return zonefile.Export(a.stdout, domain, entries)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := zonefile.Export(f, domain, entries); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// planZone reads a zone file and returns the live records of domain and the
+// changes needed to match the file.
+func (a *app) planZone(ctx context.Context, domain, file string) ([]zonefile.Change, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	desired, err := zonefile.Parse(f, domain)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+
+	live, err := a.client.ListEntries(ctx, domain)
+	if err != nil {
+		return nil, fmt.Errorf("listing records of %s: %w", domain, err)
+	}
+
+	return zonefile.Diff(domain, live, desired), nil
+}
+
+func (a *app) diff(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+
+	values, err := a.parse(fs, args, "domain", "file")
+	if err != nil {
+		return err
+	}
+
+	changes, err := a.planZone(ctx, values[0], values[1])
+	if err != nil {
+		return err
+	}
+	return a.writeChanges(changes)
+}
+
+func (a *app) importZone(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("zone import", flag.ContinueOnError)
+	prune := fs.Bool("prune", false, "delete live records missing from the zone file, except the apex NS records")
+	dryRun := fs.Bool("dry-run", false, "only show the changes")
+
+	values, err := a.parse(fs, args, "domain", "file")
+	if err != nil {
+		return err
+	}
+	domain := values[0]
+
+	changes, err := a.planZone(ctx, domain, values[1])
+	if err != nil {
+		return err
+	}
+
+	var applied []zonefile.Change
+	for _, c := range changes {
+		if c.Op == zonefile.OpDelete && (!*prune || isApexNS(domain, *c.Live)) {
+			continue
+		}
+		applied = append(applied, c)
+	}
+
+	// Deletes go first so that a record can replace one it conflicts with,
+	// e.g. an A record replacing a CNAME.
+	slices.SortStableFunc(applied, func(x, y zonefile.Change) int {
+		return applyOrder[x.Op] - applyOrder[y.Op]
+	})
+
+	if *dryRun {
+		return a.writeChanges(applied)
+	}
+
+	for i, c := range applied {
+		if err := a.apply(ctx, domain, c); err != nil {
+			// Show what was already changed before giving up.
+			if werr := a.writeChanges(applied[:i]); werr != nil {
+				return werr
+			}
+			return fmt.Errorf("%w (%d of %d changes applied)", err, i, len(applied))
+		}
+	}
+	return a.writeChanges(applied)
+}
+
+// applyOrder is the order in which zone import applies the kinds of changes.
+var applyOrder = map[string]int{
+	zonefile.OpDelete: 0,
+	zonefile.OpUpdate: 1,
+	zonefile.OpAdd:    2,
+}
+
+func (a *app) apply(ctx context.Context, domain string, c zonefile.Change) error {
+	switch c.Op {
+	case zonefile.OpAdd:
+		if _, err := a.client.CreateEntry(ctx, domain, *c.Desired); err != nil {
+			return fmt.Errorf("adding %s %s: %w", c.Desired.Host, c.Desired.Type, err)
+		}
+	case zonefile.OpUpdate:
+		if _, err := a.client.UpdateEntry(ctx, domain, c.Live.ID, *c.Desired); err != nil {
+			return fmt.Errorf("updating record %d: %w", c.Live.ID, err)
+		}
+	case zonefile.OpDelete:
+		if err := a.client.DeleteEntry(ctx, domain, c.Live.ID); err != nil {
+			return fmt.Errorf("deleting record %d: %w", c.Live.ID, err)
+		}
+	}
+	return nil
+}
+
+func isApexNS(domain string, e client.Entry) bool {
+	return strings.EqualFold(e.Type, "NS") && client.CanonicalHost(e.Host, domain) == "@"
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/mglants/terraform-provider-fornex/internal/provider"
+	"github.com/mglants/terraform-provider-fornex/internal/tracing"
 )
 
 var (
@@ -26,7 +27,19 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
+
+	shutdown, err := tracing.Setup(ctx, version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	// Flush spans before exiting, log.Fatal would skip deferred calls.
+	if serr := shutdown(ctx); serr != nil {
+		log.Printf("unable to flush traces: %s", serr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())
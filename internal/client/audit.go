@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Audit outcomes.
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// AuditEntry is a single line of the audit log.
+type AuditEntry struct {
+	Time      time.Time `json:"time"`
+	Actor     string    `json:"actor,omitempty"`
+	Operation string    `json:"operation"`
+	Domain    string    `json:"domain"`
+	EntryID   int       `json:"entry_id,omitempty"`
+	Request   any       `json:"request,omitempty"`
+	Before    any       `json:"before,omitempty"`
+	After     any       `json:"after,omitempty"`
+	Outcome   string    `json:"outcome"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AuditLog appends mutation records to a JSON Lines file. It is safe for
+// concurrent use; every entry is written with a single append.
+type AuditLog struct {
+	mu    sync.Mutex
+	path  string
+	actor string
+}
+
+// NewAuditLog returns an audit log writing to path, creating the file if
+// needed. Actor identifies who made the changes; it defaults to the current
+// user and host.
+func NewAuditLog(path, actor string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+
+	if actor == "" {
+		actor = defaultActor()
+	}
+	return &AuditLog{path: path, actor: actor}, nil
+}
+
+// Write appends an entry to the log, filling in time and actor.
+func (a *AuditLog) Write(entry AuditEntry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	if entry.Actor == "" {
+		entry.Actor = a.actor
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func defaultActor() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		name += "@" + host
+	}
+	return name
+}
+
+// audit records the outcome of a mutation if an audit log is configured.
+// Failing to write the log does not fail the mutation, which has already
+// happened, but is reported in the provider logs.
+func (c *Client) audit(ctx context.Context, entry AuditEntry, err error) {
+	if c.AuditLog == nil {
+		return
+	}
+
+	entry.Outcome = AuditSuccess
+	if err != nil {
+		entry.Outcome = AuditFailure
+		entry.Error = err.Error()
+		entry.After = nil
+	}
+
+	if werr := c.AuditLog.Write(entry); werr != nil {
+		tflog.SubsystemError(c.logContext(ctx), LogSubsystem, "Unable to write audit log", map[string]any{
+			"operation": entry.Operation,
+			"error":     werr.Error(),
+		})
+	}
+}
+
+// auditBefore fetches the current state of an entry for the audit log. It
+// returns nil, not a nil *Entry, when auditing is disabled or the entry cannot
+// be read, so that the entry leaves out "before".
+func (c *Client) auditBefore(ctx context.Context, domainName string, entryID int) any {
+	if c.AuditLog == nil {
+		return nil
+	}
+	entry, err := c.GetEntry(ctx, domainName, entryID)
+	if err != nil {
+		return nil
+	}
+	return entry
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func readAuditLog(t *testing.T, path string) []map[string]any {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var entries []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Invalid audit log line %q: %s", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAuditLogUpdateEntry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(Entry{ID: 7, Host: "www", Type: "A", Value: "1.1.1.1"})
+		case "PUT":
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(Entry{ID: 7, Host: "www", Type: "A", Value: "2.2.2.2"})
+		case "DELETE":
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := NewAuditLog(path, "ci-pipeline")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	client := NewClient("test-key", server.URL)
+	client.AuditLog = auditLog
+
+	if _, err := client.UpdateEntry(context.Background(), "example.com", 7, Entry{Host: "www", Type: "A", Value: "2.2.2.2"}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if err := client.DeleteEntry(context.Background(), "example.com", 7); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	entries := readAuditLog(t, path)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 audit entries, got: %d", len(entries))
+	}
+
+	update := entries[0]
+	if update["operation"] != "UpdateEntry" || update["outcome"] != AuditSuccess || update["actor"] != "ci-pipeline" {
+		t.Errorf("Unexpected update entry: %v", update)
+	}
+	if update["before"].(map[string]any)["value"] != "1.1.1.1" || update["after"].(map[string]any)["value"] != "2.2.2.2" {
+		t.Errorf("Expected before and after payloads, got: %v", update)
+	}
+	if update["time"] == "" {
+		t.Errorf("Expected timestamp, got: %v", update)
+	}
+
+	del := entries[1]
+	if del["operation"] != "DeleteEntry" || del["outcome"] != AuditFailure || del["error"] == "" {
+		t.Errorf("Unexpected delete entry: %v", del)
+	}
+}
+
+func TestAuditLogConcurrentWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := NewAuditLog(path, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = auditLog.Write(AuditEntry{Operation: "CreateEntry", Domain: fmt.Sprintf("d%d.com", i), Outcome: AuditSuccess})
+		}()
+	}
+	wg.Wait()
+
+	if entries := readAuditLog(t, path); len(entries) != 50 {
+		t.Errorf("Expected 50 audit entries, got: %d", len(entries))
+	}
+}
+
+func TestAuditLogDomainBefore(t *testing.T) {
+	// The domain can only be read before the first change.
+	var reads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			reads++
+			if reads > 1 {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(Domain{Name: "example.com", Tags: []string{"prod"}})
+		case "PATCH":
+			_ = json.NewEncoder(w).Encode(Domain{Name: "example.com", Tags: []string{"web"}})
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := NewAuditLog(path, "ci-pipeline")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	client := NewClient("test-key", server.URL)
+	client.AuditLog = auditLog
+
+	if _, err := client.UpdateDomainTags(context.Background(), "example.com", []string{"web"}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if err := client.DeleteDomain(context.Background(), "example.com"); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	entries := readAuditLog(t, path)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 audit entries, got: %d", len(entries))
+	}
+
+	update := entries[0]
+	before, ok := update["before"].(map[string]any)
+	if !ok || before["name"] != "example.com" || update["after"] == nil {
+		t.Errorf("Expected before and after payloads, got: %v", update)
+	}
+
+	del := entries[1]
+	if _, ok := del["before"]; ok || del["outcome"] != AuditSuccess {
+		t.Errorf("Expected no before payload when the domain cannot be read, got: %v", del)
+	}
+}
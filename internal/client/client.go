@@ -2,20 +2,61 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 const DefaultBaseURL = "https://fornex.com/api"
 
+// DefaultNameservers are the Fornex nameservers a zone is delegated to when the
+// zone itself does not list any apex NS records. The API does not report the
+// delegation of a zone, so these are an assumption.
+var DefaultNameservers = []string{"ns1.fornex.com", "ns2.fornex.com", "ns3.fornex.com"}
+
+const DefaultRequestTimeout = time.Minute
+
+// DefaultUserAgent is sent when no more specific User-Agent is configured.
+const DefaultUserAgent = "terraform-provider-fornex"
+
 type Client struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+	UserAgent  string
+	// ReadOnly makes the client refuse every request that could modify data.
+	ReadOnly bool
+	// AuditLog, if set, records every mutation and its outcome.
+	AuditLog *AuditLog
+	// TracerProvider creates the spans for API requests. The global
+	// OpenTelemetry tracer provider is used when nil.
+	TracerProvider trace.TracerProvider
+
+	// directGetUnsupported is set once the API answered 405 on a single
+	// object endpoint, after which lookups go straight to the list endpoint.
+	directGetUnsupported atomic.Bool
+}
+
+// ErrReadOnly is returned for mutating requests made by a read-only client.
+var ErrReadOnly = errors.New("client is in read-only mode")
+
+// APIError is returned when the API responds with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
 }
 
 func NewClient(apiKey string, baseURL string) *Client {
@@ -26,36 +67,112 @@ func NewClient(apiKey string, baseURL string) *Client {
 	baseURL = strings.TrimSuffix(baseURL, "/")
 
 	return &Client{
-		BaseURL: baseURL,
-		APIKey:  apiKey,
+		BaseURL:   baseURL,
+		APIKey:    apiKey,
+		UserAgent: DefaultUserAgent,
 		HTTPClient: &http.Client{
-			Timeout: time.Minute,
+			Timeout: DefaultRequestTimeout,
 		},
 	}
 }
 
+// UserAgent builds the User-Agent for requests made by the provider, e.g.
+// "terraform-provider-fornex/1.2.0 (+terraform 1.9.5) ci-pipeline".
+func UserAgent(providerVersion, terraformVersion, suffix string) string {
+	if providerVersion == "" {
+		providerVersion = "dev"
+	}
+	ua := fmt.Sprintf("%s/%s", DefaultUserAgent, providerVersion)
+	if terraformVersion != "" {
+		ua += fmt.Sprintf(" (+terraform %s)", terraformVersion)
+	}
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
+}
+
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+	if c.ReadOnly && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, fmt.Errorf("%w: refusing %s %s", ErrReadOnly, req.Method, req.URL.Path)
+	}
+
 	req.Header.Set("Authorization", fmt.Sprintf("Api-Key %s", c.APIKey))
 	req.Header.Set("Content-Type", "application/json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
 
-	res, err := c.HTTPClient.Do(req)
+	req, span := c.startSpan(req)
+	defer span.End()
+
+	status, body, err := c.send(req)
+	if err == nil && (status < 200 || status >= 300) {
+		err = &APIError{StatusCode: status, Body: string(body)}
+	}
+	endSpan(span, status, err)
 	if err != nil {
 		return nil, err
 	}
+
+	return body, nil
+}
+
+// send performs the HTTP round trip with logging and returns the status code
+// and body of the response.
+func (c *Client) send(req *http.Request) (int, []byte, error) {
+	ctx := c.logContext(req.Context())
+	logRequest(ctx, req)
+	start := time.Now()
+
+	res, err := c.HTTPClient.Do(req)
+	if err != nil {
+		logFailure(ctx, req, err, time.Since(start))
+		return 0, nil, err
+	}
 	defer func() {
 		_ = res.Body.Close()
 	}()
 
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		logFailure(ctx, req, err, time.Since(start))
+		return 0, nil, err
 	}
+	logResponse(ctx, req, res, body, time.Since(start))
+
+	return res.StatusCode, body, nil
+}
 
-	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, string(body))
+// CheckCredentials performs a single cheap authenticated request to verify
+// that the API is reachable and accepts the API key.
+func (c *Client) CheckCredentials(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/dns/domain/", c.BaseURL), nil)
+	if err != nil {
+		return err
 	}
 
-	return body, nil
+	_, err = c.doRequest(req)
+	return err
+}
+
+// RecordTypes are the record types supported by the Fornex API.
+var RecordTypes = []string{"A", "AAAA", "CAA", "CNAME", "MX", "NS", "SRV", "TXT"}
+
+// CanonicalHost returns host relative to domain in lower case, with "@" for
+// the apex. host may be relative or a fully qualified name of domain.
+func CanonicalHost(host, domain string) string {
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	switch {
+	case host == "" || host == "@" || host == domain:
+		return "@"
+	case strings.HasSuffix(host, "."+domain):
+		return strings.TrimSuffix(host, "."+domain)
+	default:
+		return host
+	}
 }
 
 // Domain types
@@ -67,11 +184,41 @@ type Domain struct {
 	Tags     []string `json:"tags"`
 }
 
+// Nameservers returns the authoritative nameservers for the domain, taken from
+// the apex NS entries of the zone and falling back to DefaultNameservers.
+func (d *Domain) Nameservers() []string {
+	var nameservers []string
+	for _, e := range d.EntrySet {
+		if !strings.EqualFold(e.Type, "NS") || CanonicalHost(e.Host, d.Name) != "@" {
+			continue
+		}
+		nameservers = append(nameservers, strings.TrimSuffix(e.Value, "."))
+	}
+	if len(nameservers) == 0 {
+		return append([]string(nil), DefaultNameservers...)
+	}
+	return nameservers
+}
+
+// HasTags reports whether the domain has all of the given tags.
+func (d *Domain) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(d.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
 type DomainRequest struct {
 	Name string `json:"name"`
 	IP   string `json:"ip"`
 }
 
+type DomainTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
 // Entry types
 type Entry struct {
 	ID       int    `json:"id,omitempty"`
@@ -83,30 +230,22 @@ type Entry struct {
 }
 
 // Domain methods
-func (c *Client) ListDomains() ([]Domain, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/dns/domain/", c.BaseURL), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	var domains []Domain
-	err = json.Unmarshal(body, &domains)
-	return domains, err
+func (c *Client) ListDomains(ctx context.Context) ([]Domain, error) {
+	return collect(c.Domains(ctx))
 }
 
-func (c *Client) CreateDomain(name, ip string) (*Domain, error) {
+func (c *Client) CreateDomain(ctx context.Context, name, ip string) (result *Domain, err error) {
 	dr := DomainRequest{Name: name, IP: ip}
+	defer func() {
+		c.audit(ctx, AuditEntry{Operation: "CreateDomain", Domain: name, Request: dr, After: result}, err)
+	}()
+
 	data, err := json.Marshal(dr)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/dns/domain/", c.BaseURL), bytes.NewBuffer(data))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/dns/domain/", c.BaseURL), bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -121,8 +260,18 @@ func (c *Client) CreateDomain(name, ip string) (*Domain, error) {
 	return &domain, err
 }
 
-func (c *Client) DeleteDomain(name string) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/dns/domain/%s/", c.BaseURL, name), nil)
+func (c *Client) DeleteDomain(ctx context.Context, name string) (err error) {
+	if c.AuditLog != nil {
+		entry := AuditEntry{Operation: "DeleteDomain", Domain: name}
+		if before, err := c.GetDomain(ctx, name); err == nil {
+			entry.Before = before
+		}
+		defer func() {
+			c.audit(ctx, entry, err)
+		}()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/dns/domain/%s/", c.BaseURL, name), nil)
 	if err != nil {
 		return err
 	}
@@ -131,22 +280,28 @@ func (c *Client) DeleteDomain(name string) error {
 	return err
 }
 
-func (c *Client) GetDomain(name string) (*Domain, error) {
-	domains, err := c.ListDomains()
-	if err != nil {
-		return nil, err
+func (c *Client) UpdateDomainTags(ctx context.Context, name string, tags []string) (result *Domain, err error) {
+	if tags == nil {
+		tags = []string{}
 	}
-	for _, d := range domains {
-		if d.Name == name {
-			return &d, nil
+	tr := DomainTagsRequest{Tags: tags}
+	if c.AuditLog != nil {
+		entry := AuditEntry{Operation: "UpdateDomainTags", Domain: name, Request: tr}
+		if before, err := c.GetDomain(ctx, name); err == nil {
+			entry.Before = before
 		}
+		defer func() {
+			entry.After = result
+			c.audit(ctx, entry, err)
+		}()
 	}
-	return nil, fmt.Errorf("domain %s not found", name)
-}
 
-// Entry methods
-func (c *Client) ListEntries(domainName string) ([]Entry, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/dns/domain/%s/entry_set/", c.BaseURL, domainName), nil)
+	data, err := json.Marshal(tr)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/dns/domain/%s/", c.BaseURL, name), bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -156,18 +311,50 @@ func (c *Client) ListEntries(domainName string) ([]Entry, error) {
 		return nil, err
 	}
 
-	var entries []Entry
-	err = json.Unmarshal(body, &entries)
-	return entries, err
+	var domain Domain
+	err = json.Unmarshal(body, &domain)
+	return &domain, err
 }
 
-func (c *Client) CreateEntry(domainName string, entry Entry) (*Entry, error) {
+func (c *Client) GetDomain(ctx context.Context, name string) (*Domain, error) {
+	if !c.directGetUnsupported.Load() {
+		var domain Domain
+		err := c.getJSON(ctx, fmt.Sprintf("%s/dns/domain/%s/", c.BaseURL, name), &domain)
+		if err == nil {
+			return &domain, nil
+		}
+		if !c.fallBackToList(err) {
+			return nil, err
+		}
+	}
+
+	for d, err := range c.Domains(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if d.Name == name {
+			return &d, nil
+		}
+	}
+	return nil, fmt.Errorf("domain %s not found", name)
+}
+
+// Entry methods
+func (c *Client) ListEntries(ctx context.Context, domainName string) ([]Entry, error) {
+	return collect(c.Entries(ctx, domainName))
+}
+
+func (c *Client) CreateEntry(ctx context.Context, domainName string, entry Entry) (result *Entry, err error) {
+	defer func() {
+		c.audit(ctx, AuditEntry{Operation: "CreateEntry", Domain: domainName, Request: entry, After: result}, err)
+	}()
+
 	data, err := json.Marshal(entry)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/dns/domain/%s/entry_set/", c.BaseURL, domainName), bytes.NewBuffer(data))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/dns/domain/%s/entry_set/", c.BaseURL, domainName), bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -182,13 +369,23 @@ func (c *Client) CreateEntry(domainName string, entry Entry) (*Entry, error) {
 	return &newEntry, err
 }
 
-func (c *Client) UpdateEntry(domainName string, entryID int, entry Entry) (*Entry, error) {
+func (c *Client) UpdateEntry(ctx context.Context, domainName string, entryID int, entry Entry) (result *Entry, err error) {
+	if c.AuditLog != nil {
+		before := c.auditBefore(ctx, domainName, entryID)
+		defer func() {
+			c.audit(ctx, AuditEntry{
+				Operation: "UpdateEntry", Domain: domainName, EntryID: entryID,
+				Request: entry, Before: before, After: result,
+			}, err)
+		}()
+	}
+
 	data, err := json.Marshal(entry)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/dns/domain/%s/entry_set/%d/", c.BaseURL, domainName, entryID), bytes.NewBuffer(data))
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/dns/domain/%s/entry_set/%d/", c.BaseURL, domainName, entryID), bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -203,8 +400,15 @@ func (c *Client) UpdateEntry(domainName string, entryID int, entry Entry) (*Entr
 	return &updatedEntry, err
 }
 
-func (c *Client) DeleteEntry(domainName string, entryID int) error {
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/dns/domain/%s/entry_set/%d/", c.BaseURL, domainName, entryID), nil)
+func (c *Client) DeleteEntry(ctx context.Context, domainName string, entryID int) (err error) {
+	if c.AuditLog != nil {
+		before := c.auditBefore(ctx, domainName, entryID)
+		defer func() {
+			c.audit(ctx, AuditEntry{Operation: "DeleteEntry", Domain: domainName, EntryID: entryID, Before: before}, err)
+		}()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/dns/domain/%s/entry_set/%d/", c.BaseURL, domainName, entryID), nil)
 	if err != nil {
 		return err
 	}
@@ -213,15 +417,59 @@ func (c *Client) DeleteEntry(domainName string, entryID int) error {
 	return err
 }
 
-func (c *Client) GetEntry(domainName string, entryID int) (*Entry, error) {
-	entries, err := c.ListEntries(domainName)
-	if err != nil {
-		return nil, err
+func (c *Client) GetEntry(ctx context.Context, domainName string, entryID int) (*Entry, error) {
+	if !c.directGetUnsupported.Load() {
+		var entry Entry
+		err := c.getJSON(ctx, fmt.Sprintf("%s/dns/domain/%s/entry_set/%d/", c.BaseURL, domainName, entryID), &entry)
+		if err == nil {
+			return &entry, nil
+		}
+		if !c.fallBackToList(err) {
+			return nil, err
+		}
 	}
-	for _, e := range entries {
+
+	for e, err := range c.Entries(ctx, domainName) {
+		if err != nil {
+			return nil, err
+		}
 		if e.ID == entryID {
 			return &e, nil
 		}
 	}
 	return nil, fmt.Errorf("entry %d not found in domain %s", entryID, domainName)
 }
+
+func (c *Client) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// fallBackToList reports whether a failed single object lookup should be
+// retried through the list endpoint. A 405 means the API does not offer the
+// direct route at all, so later lookups skip it.
+func (c *Client) fallBackToList(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusMethodNotAllowed:
+		c.directGetUnsupported.Store(true)
+		return true
+	case http.StatusNotFound:
+		return true
+	default:
+		return false
+	}
+}
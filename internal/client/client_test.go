@@ -1,11 +1,15 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestListDomains(t *testing.T) {
@@ -26,7 +30,7 @@ func TestListDomains(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-key", server.URL)
-	domains, err := client.ListDomains()
+	domains, err := client.ListDomains(context.Background())
 
 	if err != nil {
 		t.Fatalf("Expected no error, got: %s", err)
@@ -60,7 +64,7 @@ func TestCreateDomain(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-key", server.URL)
-	domain, err := client.CreateDomain("new.com", "1.1.1.1")
+	domain, err := client.CreateDomain(context.Background(), "new.com", "1.1.1.1")
 
 	if err != nil {
 		t.Fatalf("Expected no error, got: %s", err)
@@ -84,13 +88,109 @@ func TestDeleteDomain(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-key", server.URL)
-	err := client.DeleteDomain("example.com")
+	err := client.DeleteDomain(context.Background(), "example.com")
 
 	if err != nil {
 		t.Fatalf("Expected no error, got: %s", err)
 	}
 }
 
+func TestUpdateDomainTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" {
+			t.Errorf("Expected PATCH request, got: %s", r.Method)
+		}
+		if r.URL.Path != "/dns/domain/example.com/" {
+			t.Errorf("Expected path '/dns/domain/example.com/', got: %s", r.URL.Path)
+		}
+
+		var req DomainTagsRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Tags) != 2 || req.Tags[0] != "team-a" || req.Tags[1] != "prod" {
+			t.Errorf("Unexpected request body: %+v", req)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		domain := Domain{Name: "example.com", Tags: req.Tags}
+		_ = json.NewEncoder(w).Encode(domain)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	domain, err := client.UpdateDomainTags(context.Background(), "example.com", []string{"team-a", "prod"})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if len(domain.Tags) != 2 {
+		t.Errorf("Expected 2 tags, got: %v", domain.Tags)
+	}
+}
+
+func TestUpdateDomainTagsClear(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"tags":[]}` {
+			t.Errorf("Expected empty tag list in body, got: %s", body)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(Domain{Name: "example.com"})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	if _, err := client.UpdateDomainTags(context.Background(), "example.com", nil); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+}
+
+func TestDomainNameservers(t *testing.T) {
+	domain := Domain{
+		Name: "example.com",
+		EntrySet: []Entry{
+			{Host: "@", Type: "NS", Value: "ns1.example.net."},
+			{Host: "example.com", Type: "NS", Value: "ns2.example.net"},
+			{Host: "sub", Type: "NS", Value: "ns.sub.example.com"},
+			{Host: "@", Type: "A", Value: "1.2.3.4"},
+		},
+	}
+
+	nameservers := domain.Nameservers()
+	if len(nameservers) != 2 || nameservers[0] != "ns1.example.net" || nameservers[1] != "ns2.example.net" {
+		t.Errorf("Unexpected nameservers: %v", nameservers)
+	}
+
+	empty := Domain{Name: "example.com"}
+	if got := empty.Nameservers(); len(got) != len(DefaultNameservers) {
+		t.Errorf("Expected default nameservers, got: %v", got)
+	}
+}
+
+func TestCanonicalHost(t *testing.T) {
+	tests := map[string]string{
+		"":                  "@",
+		"@":                 "@",
+		"example.com":       "@",
+		"Example.COM.":      "@",
+		"www":               "www",
+		" WWW ":             "www",
+		"www.example.com":   "www",
+		"www.example.com.":  "www",
+		"a.b.example.com":   "a.b",
+		"www.example.net":   "www.example.net",
+		"notexample.com":    "notexample.com",
+		"*.Sub.example.com": "*.sub",
+	}
+
+	for host, expected := range tests {
+		if got := CanonicalHost(host, "example.com."); got != expected {
+			t.Errorf("CanonicalHost(%q): expected %q, got: %q", host, expected, got)
+		}
+	}
+}
+
 func TestErrorHandling(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -99,7 +199,7 @@ func TestErrorHandling(t *testing.T) {
 	defer server.Close()
 
 	client := NewClient("test-key", server.URL)
-	_, err := client.ListDomains()
+	_, err := client.ListDomains(context.Background())
 
 	if err == nil {
 		t.Fatal("Expected error, got nil")
@@ -109,3 +209,188 @@ func TestErrorHandling(t *testing.T) {
 		t.Errorf("Expected error to contain status 400, got: %s", err)
 	}
 }
+
+func TestRequestContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	client := NewClient("test-key", server.URL)
+	_, err := client.ListDomains(ctx)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context deadline error, got: %v", err)
+	}
+}
+
+func TestGetDomainDirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dns/domain/example.com/" {
+			t.Errorf("Expected path '/dns/domain/example.com/', got: %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(Domain{Name: "example.com", Created: "2024-01-01"})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	domain, err := client.GetDomain(context.Background(), "example.com")
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if domain.Created != "2024-01-01" {
+		t.Errorf("Expected created '2024-01-01', got: %s", domain.Created)
+	}
+}
+
+func TestGetDomainFallback(t *testing.T) {
+	direct := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/dns/domain/example.com/" {
+			direct++
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]Domain{{Name: "other.com"}, {Name: "example.com"}})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	for range 2 {
+		domain, err := client.GetDomain(context.Background(), "example.com")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+		if domain.Name != "example.com" {
+			t.Errorf("Expected domain name 'example.com', got: %s", domain.Name)
+		}
+	}
+
+	if direct != 1 {
+		t.Errorf("Expected direct route to be tried once, got: %d", direct)
+	}
+}
+
+func TestGetEntryFallbackNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dns/domain/example.com/entry_set/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode([]Entry{{ID: 1, Host: "@"}, {ID: 2, Host: "www"}})
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	entry, err := client.GetEntry(context.Background(), "example.com", 2)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if entry.Host != "www" {
+		t.Errorf("Expected host 'www', got: %s", entry.Host)
+	}
+
+	_, err = client.GetEntry(context.Background(), "example.com", 3)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got: %v", err)
+	}
+}
+
+func TestGetEntryDirectError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dns/domain/example.com/entry_set/1/" {
+			t.Errorf("Expected no list fallback, got request to: %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	_, err := client.GetEntry(context.Background(), "example.com", 1)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("Expected APIError with status 500, got: %v", err)
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "terraform-provider-fornex/1.2.0 (+terraform 1.9.5) pipeline/deploy-dns"
+		if r.Header.Get("User-Agent") != want {
+			t.Errorf("Expected User-Agent %q, got: %q", want, r.Header.Get("User-Agent"))
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	client.UserAgent = UserAgent("1.2.0", "1.9.5", "pipeline/deploy-dns")
+	if _, err := client.ListDomains(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if got := UserAgent("", "", ""); got != "terraform-provider-fornex/dev" {
+		t.Errorf("Expected 'terraform-provider-fornex/dev', got: %s", got)
+	}
+}
+
+func TestCheckCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Api-Key good-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"detail":"Invalid token."}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	if err := NewClient("good-key", server.URL).CheckCredentials(context.Background()); err != nil {
+		t.Errorf("Expected no error, got: %s", err)
+	}
+
+	err := NewClient("bad-key", server.URL).CheckCredentials(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected APIError with status 401, got: %v", err)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("Expected only GET requests to reach the server, got: %s", r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	client.ReadOnly = true
+
+	if _, err := client.ListDomains(context.Background()); err != nil {
+		t.Errorf("Expected no error, got: %s", err)
+	}
+
+	if _, err := client.CreateEntry(context.Background(), "example.com", Entry{Host: "www"}); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got: %v", err)
+	}
+
+	if err := client.DeleteDomain(context.Background(), "example.com"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Expected ErrReadOnly, got: %v", err)
+	}
+}
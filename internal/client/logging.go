@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem API traffic is logged under. Its level
+// can be set separately through TF_LOG_PROVIDER_FORNEX_CLIENT.
+const LogSubsystem = "fornex-client"
+
+// maxLoggedBody is the number of body bytes included in a log entry.
+const maxLoggedBody = 2048
+
+var sensitiveBodyFields = regexp.MustCompile(`"(api_key|apikey|password|secret|token)"\s*:\s*"[^"]*"`)
+
+// logContext sets up the client log subsystem with masking of credentials.
+func (c *Client) logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_FORNEX_CLIENT"))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, LogSubsystem, "authorization")
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, LogSubsystem, sensitiveBodyFields)
+	if c.APIKey != "" {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, LogSubsystem, c.APIKey)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, LogSubsystem, c.APIKey)
+	}
+	return ctx
+}
+
+func logRequest(ctx context.Context, req *http.Request) {
+	fields := map[string]any{
+		"method":        req.Method,
+		"url":           req.URL.String(),
+		"authorization": req.Header.Get("Authorization"),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			_ = body.Close()
+			fields["body"] = truncateBody(data)
+		}
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Sending API request", fields)
+}
+
+func logResponse(ctx context.Context, req *http.Request, res *http.Response, body []byte, latency time.Duration) {
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received API response", map[string]any{
+		"method":     req.Method,
+		"url":        req.URL.String(),
+		"status":     res.StatusCode,
+		"latency_ms": latency.Milliseconds(),
+		"request_id": requestID(res),
+		"body":       truncateBody(body),
+	})
+}
+
+func logFailure(ctx context.Context, req *http.Request, err error, latency time.Duration) {
+	tflog.SubsystemError(ctx, LogSubsystem, "API request failed", map[string]any{
+		"method":     req.Method,
+		"url":        req.URL.String(),
+		"latency_ms": latency.Milliseconds(),
+		"error":      err.Error(),
+	})
+}
+
+func requestID(res *http.Response) string {
+	for _, h := range []string{"X-Request-Id", "X-Correlation-Id"} {
+		if v := res.Header.Get(h); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func truncateBody(body []byte) string {
+	if len(body) <= maxLoggedBody {
+		return string(body)
+	}
+	return string(body[:maxLoggedBody]) + "... (truncated)"
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRequestLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(Entry{ID: 1, Host: "www", Type: "TXT", Value: "secret-key-value"})
+	}))
+	defer server.Close()
+
+	t.Setenv("TF_LOG_PROVIDER_FORNEX_CLIENT", "DEBUG")
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := NewClient("secret-key-value", server.URL)
+	if _, err := client.CreateEntry(ctx, "example.com", Entry{Host: "www", Type: "TXT", Value: "hello"}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if strings.Contains(output.String(), "secret-key-value") {
+		t.Errorf("Expected API key to be masked, got: %s", output.String())
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 log entries, got: %d", len(entries))
+	}
+
+	request, response := entries[0], entries[1]
+	if request["@module"] != "provider."+LogSubsystem {
+		t.Errorf("Expected module 'provider.%s', got: %v", LogSubsystem, request["@module"])
+	}
+	if request["method"] != "POST" || !strings.Contains(request["body"].(string), `"host":"www"`) {
+		t.Errorf("Unexpected request entry: %v", request)
+	}
+	if request["authorization"] != "***" {
+		t.Errorf("Expected masked authorization, got: %v", request["authorization"])
+	}
+	if response["status"] != float64(http.StatusCreated) || response["request_id"] != "req-123" {
+		t.Errorf("Unexpected response entry: %v", response)
+	}
+	if _, ok := response["latency_ms"]; !ok {
+		t.Errorf("Expected latency_ms in response entry: %v", response)
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	body := bytes.Repeat([]byte("a"), maxLoggedBody+10)
+	got := truncateBody(body)
+
+	if !strings.HasSuffix(got, "(truncated)") || len(got) > maxLoggedBody+20 {
+		t.Errorf("Expected truncated body, got %d bytes", len(got))
+	}
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// listPage is a DRF-style paginated list envelope.
+type listPage struct {
+	Count   int             `json:"count"`
+	Next    *string         `json:"next"`
+	Results json.RawMessage `json:"results"`
+}
+
+// decodePage decodes a list response that is either a bare JSON array or a
+// paginated envelope, returning the items and the URL of the next page.
+func decodePage[T any](body []byte) ([]T, string, error) {
+	var items []T
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		err := json.Unmarshal(trimmed, &items)
+		return items, "", err
+	}
+
+	var p listPage
+	if err := json.Unmarshal(trimmed, &p); err != nil {
+		return nil, "", err
+	}
+	if p.Results == nil {
+		return nil, "", fmt.Errorf("unexpected list response: %s", string(body))
+	}
+	if err := json.Unmarshal(p.Results, &items); err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if p.Next != nil {
+		next = *p.Next
+	}
+	return items, next, nil
+}
+
+// paginate yields every item of the list at rawURL, following next links
+// until the last page. Iteration stops at the first error.
+func paginate[T any](ctx context.Context, c *Client, rawURL string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		seen := map[string]bool{}
+
+		for rawURL != "" {
+			if seen[rawURL] {
+				yield(zero, fmt.Errorf("pagination loop detected at %s", rawURL))
+				return
+			}
+			seen[rawURL] = true
+
+			req, err := http.NewRequestWithContext(ctx, "GET", rawURL, nil)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			body, err := c.doRequest(req)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, next, err := decodePage[T](body)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			rawURL, err = c.resolveNext(req.URL, next)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+	}
+}
+
+// resolveNext resolves a possibly relative next link against the current
+// request URL. The API key is sent with every page, so links to another host
+// are refused, and links whose scheme differs from BaseURL, such as the
+// http:// links of an API behind a TLS-terminating proxy, are rewritten to
+// the BaseURL scheme.
+func (c *Client) resolveNext(current *url.URL, next string) (string, error) {
+	if next == "" {
+		return "", nil
+	}
+	u, err := url.Parse(next)
+	if err != nil {
+		return "", fmt.Errorf("invalid next page link %q: %w", next, err)
+	}
+	u = current.ResolveReference(u)
+
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(u.Host, base.Host) {
+		return "", fmt.Errorf("refusing to follow next page link %q to a host other than %s", next, base.Host)
+	}
+	u.Scheme = base.Scheme
+
+	return u.String(), nil
+}
+
+// collect drains a paginated iterator into a slice.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Domains iterates over all domains, fetching further pages on demand.
+func (c *Client) Domains(ctx context.Context) iter.Seq2[Domain, error] {
+	return paginate[Domain](ctx, c, fmt.Sprintf("%s/dns/domain/", c.BaseURL))
+}
+
+// Entries iterates over all entries of a domain, fetching further pages on
+// demand.
+func (c *Client) Entries(ctx context.Context, domainName string) iter.Seq2[Entry, error] {
+	return paginate[Entry](ctx, c, fmt.Sprintf("%s/dns/domain/%s/entry_set/", c.BaseURL, domainName))
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListDomainsPaginated(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Query().Get("page") {
+		case "":
+			_, _ = fmt.Fprintf(w, `{"count":3,"next":"%s/dns/domain/?page=2","previous":null,"results":[{"name":"a.com"},{"name":"b.com"}]}`, server.URL)
+		case "2":
+			// Relative next links are resolved against the current page.
+			_, _ = w.Write([]byte(`{"count":3,"next":"?page=3","results":[{"name":"c.com"}]}`))
+		case "3":
+			_, _ = w.Write([]byte(`{"count":3,"next":null,"results":[]}`))
+		default:
+			t.Errorf("Unexpected page: %s", r.URL.RawQuery)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	domains, err := client.ListDomains(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if len(domains) != 3 || domains[2].Name != "c.com" {
+		t.Errorf("Expected domains from all pages, got: %+v", domains)
+	}
+}
+
+func TestEntriesIteratorStopsEarly(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"next":"/dns/domain/example.com/entry_set/?page=2","results":[{"id":1,"host":"@","type":"A","value":"1.2.3.4"},{"id":2,"host":"www","type":"A","value":"1.2.3.4"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	for entry, err := range client.Entries(context.Background(), "example.com") {
+		if err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+		if entry.ID == 1 {
+			break
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected 1 request, got: %d", requests)
+	}
+}
+
+func TestPaginationLoop(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"next":"/dns/domain/","results":[{"name":"a.com"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	_, err := client.ListDomains(context.Background())
+
+	if err == nil || !strings.Contains(err.Error(), "pagination loop") {
+		t.Fatalf("Expected pagination loop error, got: %v", err)
+	}
+}
+
+func TestPaginationRewritesNextScheme(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("page") == "" {
+			// A TLS-terminating proxy in front of the API reports http:// links.
+			_, _ = fmt.Fprintf(w, `{"next":"%s/dns/domain/?page=2","results":[{"name":"a.com"}]}`, strings.Replace(server.URL, "https://", "http://", 1))
+			return
+		}
+		_, _ = w.Write([]byte(`{"next":null,"results":[{"name":"b.com"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	client.HTTPClient = server.Client()
+	domains, err := client.ListDomains(context.Background())
+
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if len(domains) != 2 {
+		t.Errorf("Expected domains from both pages, got: %+v", domains)
+	}
+}
+
+func TestPaginationRefusesOtherHost(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request to another host, got one with Authorization %q", r.Header.Get("Authorization"))
+	}))
+	defer other.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"next":"%s/dns/domain/?page=2","results":[{"name":"a.com"}]}`, other.URL)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-key", server.URL)
+	_, err := client.ListDomains(context.Background())
+
+	if err == nil || !strings.Contains(err.Error(), "refusing to follow") {
+		t.Fatalf("Expected next link to be refused, got: %v", err)
+	}
+}
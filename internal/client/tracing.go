@@ -0,0 +1,83 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mglants/terraform-provider-fornex/internal/tracing"
+)
+
+// startSpan starts a client span for an API request and returns the request
+// bound to the span context.
+func (c *Client) startSpan(req *http.Request) (*http.Request, trace.Span) {
+	template := c.pathTemplate(req.URL.Path)
+
+	ctx, span := tracing.Tracer(c.TracerProvider).Start(req.Context(), req.Method+" "+template,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.template", template),
+			attribute.String("server.address", req.URL.Hostname()),
+			// Requests are not retried by the client.
+			attribute.Int("http.request.resend_count", 0),
+		),
+	)
+	return req.WithContext(ctx), span
+}
+
+func endSpan(span trace.Span, status int, err error) {
+	if status != 0 {
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+	}
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		span.SetStatus(codes.Error, fmt.Sprintf("status %d", apiErr.StatusCode))
+		return
+	}
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// pathTemplate replaces the domain name and entry ID in an API path with
+// placeholders, e.g. /dns/domain/{domain}/entry_set/{id}/.
+func (c *Client) pathTemplate(path string) string {
+	if base := c.baseURLPath(); base != "" {
+		path = strings.TrimPrefix(path, base)
+	}
+
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] == "" {
+			continue
+		}
+		switch segments[i-1] {
+		case "domain":
+			segments[i] = "{domain}"
+		case "entry_set":
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (c *Client) baseURLPath() string {
+	i := strings.Index(c.BaseURL, "://")
+	if i < 0 {
+		return ""
+	}
+	rest := c.BaseURL[i+3:]
+	if j := strings.Index(rest, "/"); j >= 0 {
+		return rest[j:]
+	}
+	return ""
+}
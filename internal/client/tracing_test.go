@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingSpans(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id": 7, "host": "www", "type": "A", "value": "1.1.1.1"}`))
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	client := NewClient("test-key", server.URL)
+	client.TracerProvider = tp
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "fornex_record.Read")
+	if _, err := client.GetEntry(ctx, "example.com", 7); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if err := client.DeleteEntry(ctx, "example.com", 7); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans, got: %d", len(spans))
+	}
+
+	get, del := spans[0], spans[1]
+	if get.Name != "GET /dns/domain/{domain}/entry_set/{id}/" {
+		t.Errorf("Unexpected span name: %s", get.Name)
+	}
+	if get.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("Expected request span to be a child of the operation span")
+	}
+
+	attrs := attribute.NewSet(get.Attributes...)
+	if v, _ := attrs.Value("http.request.method"); v.AsString() != "GET" {
+		t.Errorf("Unexpected method attribute: %v", v)
+	}
+	if v, _ := attrs.Value("http.response.status_code"); v.AsInt64() != 200 {
+		t.Errorf("Unexpected status attribute: %v", v)
+	}
+	if v, _ := attrs.Value("url.template"); v.AsString() != "/dns/domain/{domain}/entry_set/{id}/" {
+		t.Errorf("Unexpected template attribute: %v", v)
+	}
+
+	if del.Status.Code != codes.Error {
+		t.Errorf("Expected error status on failed request, got: %v", del.Status)
+	}
+	delAttrs := attribute.NewSet(del.Attributes...)
+	if v, _ := delAttrs.Value("http.response.status_code"); v.AsInt64() != 500 {
+		t.Errorf("Unexpected status attribute: %v", v)
+	}
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTLSServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func clientWithTransport(t *testing.T, serverURL string, cfg TransportConfig) *Client {
+	t.Helper()
+
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	c := NewClient("test-key", serverURL)
+	c.HTTPClient.Transport = transport
+	return c
+}
+
+func TestTransportCACert(t *testing.T) {
+	server := newTLSServer(t)
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	untrusted := clientWithTransport(t, server.URL, TransportConfig{})
+	if _, err := untrusted.ListDomains(context.Background()); err == nil {
+		t.Error("Expected certificate error without CA bundle, got nil")
+	}
+
+	trusted := clientWithTransport(t, server.URL, TransportConfig{CACertPEM: caPEM})
+	if _, err := trusted.ListDomains(context.Background()); err != nil {
+		t.Errorf("Expected no error with CA bundle, got: %s", err)
+	}
+
+	insecure := clientWithTransport(t, server.URL, TransportConfig{InsecureSkipVerify: true})
+	if _, err := insecure.ListDomains(context.Background()); err != nil {
+		t.Errorf("Expected no error with insecure_skip_verify, got: %s", err)
+	}
+}
+
+func TestTransportProxy(t *testing.T) {
+	proxied := false
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.Host == "fornex.invalid"
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer proxy.Close()
+
+	client := clientWithTransport(t, "http://fornex.invalid/api", TransportConfig{ProxyURL: proxy.URL})
+	if _, err := client.ListDomains(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	if !proxied {
+		t.Error("Expected request to go through the proxy")
+	}
+}
+
+func TestTransportClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			t.Error("Expected client certificate")
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	client := clientWithTransport(t, server.URL, TransportConfig{
+		InsecureSkipVerify: true,
+		ClientCertPEM:      certPEM,
+		ClientKeyPEM:       keyPEM,
+	})
+	if _, err := client.ListDomains(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+}
+
+func TestTransportInvalidConfig(t *testing.T) {
+	certPEM, _ := generateCertificate(t)
+
+	tests := map[string]TransportConfig{
+		"proxy":       {ProxyURL: "not a url"},
+		"ca bundle":   {CACertPEM: []byte("garbage")},
+		"missing key": {ClientCertPEM: certPEM},
+	}
+
+	for name, cfg := range tests {
+		if _, err := NewTransport(cfg); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func generateCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
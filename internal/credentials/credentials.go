@@ -0,0 +1,213 @@
+// Package credentials resolves the Fornex API key from the supported sources.
+//
+// Sources are tried in the following order, the first one yielding a key wins:
+//
+//  1. an explicit API key
+//  2. an API key file
+//  3. an API key command (credential helper)
+//  4. the shared credentials file, if a profile or file is configured
+//     explicitly
+//  5. the FORNEX_API_KEY environment variable
+//  6. a profile in the shared credentials file, selected by FORNEX_PROFILE and
+//     FORNEX_SHARED_CREDENTIALS_FILE or the defaults
+package credentials
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	EnvAPIKey                = "FORNEX_API_KEY"
+	EnvProfile               = "FORNEX_PROFILE"
+	EnvSharedCredentialsFile = "FORNEX_SHARED_CREDENTIALS_FILE"
+
+	DefaultProfile = "default"
+)
+
+// ErrNotFound is returned when none of the sources yields an API key.
+var ErrNotFound = errors.New("no API key found")
+
+// Config holds the explicitly configured credential sources. Empty fields are
+// skipped.
+type Config struct {
+	APIKey                string
+	APIKeyFile            string
+	APIKeyCommand         string
+	Profile               string
+	SharedCredentialsFile string
+}
+
+// Resolve returns the API key and a short description of where it came from.
+func Resolve(ctx context.Context, cfg Config) (string, string, error) {
+	if cfg.APIKey != "" {
+		return cfg.APIKey, "api_key", nil
+	}
+
+	if cfg.APIKeyFile != "" {
+		key, err := readKeyFile(cfg.APIKeyFile)
+		if err != nil {
+			return "", "", err
+		}
+		return key, "api_key_file " + cfg.APIKeyFile, nil
+	}
+
+	if cfg.APIKeyCommand != "" {
+		key, err := runCommand(ctx, cfg.APIKeyCommand)
+		if err != nil {
+			return "", "", err
+		}
+		return key, "api_key_command", nil
+	}
+
+	// A profile or file chosen in the configuration is more specific than
+	// an API key exported in the environment.
+	if cfg.Profile != "" || cfg.SharedCredentialsFile != "" {
+		key, source, err := fromSharedCredentials(cfg)
+		if !errors.Is(err, ErrNotFound) {
+			return key, source, err
+		}
+	}
+
+	if key := os.Getenv(EnvAPIKey); key != "" {
+		return key, EnvAPIKey, nil
+	}
+
+	return fromSharedCredentials(cfg)
+}
+
+// fromSharedCredentials reads the API key of the selected profile from the
+// shared credentials file.
+func fromSharedCredentials(cfg Config) (string, string, error) {
+	profile := cfg.Profile
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+	explicitProfile := profile != ""
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	file := cfg.SharedCredentialsFile
+	if file == "" {
+		file = os.Getenv(EnvSharedCredentialsFile)
+	}
+	explicitFile := file != ""
+	if file == "" {
+		file = DefaultSharedCredentialsFile()
+	}
+
+	if file != "" {
+		profiles, err := ParseSharedCredentialsFile(file)
+		switch {
+		case errors.Is(err, os.ErrNotExist) && !explicitFile && !explicitProfile:
+			// No shared credentials file is fine unless one was asked for.
+		case err != nil:
+			return "", "", err
+		default:
+			if key := profiles[profile]["api_key"]; key != "" {
+				return key, fmt.Sprintf("profile %q in %s", profile, file), nil
+			}
+			if explicitProfile {
+				return "", "", fmt.Errorf("profile %q in %s has no api_key", profile, file)
+			}
+		}
+	}
+
+	return "", "", ErrNotFound
+}
+
+// DefaultSharedCredentialsFile returns the default location of the shared
+// credentials file, ~/.config/fornex/credentials.
+func DefaultSharedCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "fornex", "credentials")
+}
+
+// ParseSharedCredentialsFile parses an INI style credentials file:
+//
+//	[default]
+//	api_key = xxxxxxxx
+//
+//	[ci]
+//	api_key = yyyyyyyy
+func ParseSharedCredentialsFile(name string) (map[string]map[string]string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if profiles[name] == nil {
+				profiles[name] = map[string]string{}
+			}
+			current = profiles[name]
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok || current == nil {
+				return nil, fmt.Errorf("%s:%d: expected a [profile] header or key = value", name, n)
+			}
+			current[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+
+	return profiles, scanner.Err()
+}
+
+func readKeyFile(name string) (string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", fmt.Errorf("reading API key file: %w", err)
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("API key file %s is empty", name)
+	}
+	return key, nil
+}
+
+// runCommand runs a credential helper through the system shell and returns
+// its trimmed standard output.
+func runCommand(ctx context.Context, command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("running API key command: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	key := strings.TrimSpace(string(out))
+	if key == "" {
+		return "", errors.New("API key command produced no output")
+	}
+	return key, nil
+}
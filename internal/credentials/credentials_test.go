@@ -0,0 +1,117 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	return path
+}
+
+const sharedFile = `
+# Fornex credentials
+[default]
+api_key = default-key
+
+[ci]
+api_key = "ci-key"
+`
+
+func TestResolvePrecedence(t *testing.T) {
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvSharedCredentialsFile, writeFile(t, "credentials", sharedFile))
+	keyFile := writeFile(t, "key", "file-key\n")
+	otherFile := writeFile(t, "other", "[default]\napi_key = other-key\n")
+
+	type testCase struct {
+		name string
+		cfg  Config
+		want string
+	}
+
+	tests := []testCase{
+		{"explicit key", Config{APIKey: "explicit", APIKeyFile: keyFile}, "explicit"},
+		{"key file", Config{APIKeyFile: keyFile, APIKeyCommand: "echo command-key"}, "file-key"},
+		{"configured profile", Config{Profile: "ci"}, "ci-key"},
+		{"configured file", Config{SharedCredentialsFile: otherFile}, "other-key"},
+		{"environment", Config{}, "env-key"},
+	}
+	if runtime.GOOS != "windows" {
+		tests = append(tests, testCase{"command", Config{APIKeyCommand: "echo command-key"}, "command-key"})
+	}
+
+	for _, tt := range tests {
+		got, _, err := Resolve(context.Background(), tt.cfg)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %s", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestResolveProfiles(t *testing.T) {
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvSharedCredentialsFile, writeFile(t, "credentials", sharedFile))
+
+	key, _, err := Resolve(context.Background(), Config{})
+	if err != nil || key != "default-key" {
+		t.Errorf("Expected default profile key, got: %q, %v", key, err)
+	}
+
+	t.Setenv(EnvProfile, "ci")
+	key, source, err := Resolve(context.Background(), Config{})
+	if err != nil || key != "ci-key" {
+		t.Errorf("Expected ci profile key, got: %q, %v", key, err)
+	}
+	if source == "" {
+		t.Error("Expected a source description")
+	}
+
+	if _, _, err := Resolve(context.Background(), Config{Profile: "missing"}); err == nil {
+		t.Error("Expected error for missing profile, got nil")
+	}
+}
+
+func TestResolveNotFound(t *testing.T) {
+	t.Setenv(EnvAPIKey, "")
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvSharedCredentialsFile, "")
+	t.Setenv("HOME", t.TempDir())
+
+	_, _, err := Resolve(context.Background(), Config{})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got: %v", err)
+	}
+}
+
+func TestResolveCommandFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	_, _, err := Resolve(context.Background(), Config{APIKeyCommand: "echo oops >&2; exit 3"})
+	if err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestParseSharedCredentialsFileInvalid(t *testing.T) {
+	if _, err := ParseSharedCredentialsFile(writeFile(t, "credentials", "api_key = orphan\n")); err == nil {
+		t.Error("Expected error for key outside of a profile, got nil")
+	}
+}
@@ -0,0 +1,215 @@
+// Package dnscheck queries authoritative nameservers directly to find out
+// whether a record change has been propagated.
+package dnscheck
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const DefaultPort = "53"
+
+const notQueried = "not queried"
+
+// Record describes the value a nameserver is expected to serve.
+type Record struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// Checker queries a set of nameservers for a record.
+type Checker struct {
+	// Nameservers are "host" or "host:port" addresses. Port 53 is used when
+	// no port is given.
+	Nameservers []string
+	// Interval is the delay between two polling rounds.
+	Interval time.Duration
+
+	client *dns.Client
+}
+
+func NewChecker(nameservers []string, interval time.Duration) *Checker {
+	addrs := make([]string, 0, len(nameservers))
+	for _, ns := range nameservers {
+		addrs = append(addrs, withPort(ns))
+	}
+
+	return &Checker{
+		Nameservers: addrs,
+		Interval:    interval,
+		client:      &dns.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// LagError is returned by Wait when some nameservers still did not serve the
+// expected value when the context expired.
+type LagError struct {
+	Record Record
+	// Lagging maps each lagging nameserver to what it served last.
+	Lagging map[string]string
+}
+
+func (e *LagError) Error() string {
+	servers := make([]string, 0, len(e.Lagging))
+	for ns := range e.Lagging {
+		servers = append(servers, ns)
+	}
+	sort.Strings(servers)
+
+	lines := make([]string, 0, len(servers))
+	for _, ns := range servers {
+		lines = append(lines, fmt.Sprintf("%s: %s", ns, e.Lagging[ns]))
+	}
+	return fmt.Sprintf("%s %s %q not served by %d nameserver(s): %s",
+		e.Record.Name, e.Record.Type, e.Record.Value, len(servers), strings.Join(lines, "; "))
+}
+
+// Wait polls every nameserver until all of them serve the expected record or
+// ctx is done, in which case a *LagError is returned.
+func (c *Checker) Wait(ctx context.Context, record Record) error {
+	pending := make(map[string]string, len(c.Nameservers))
+	for _, ns := range c.Nameservers {
+		pending[ns] = notQueried
+	}
+
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+
+	for {
+		for ns, last := range pending {
+			ok, observed, err := c.Check(ctx, ns, record)
+			switch {
+			case err != nil:
+				// Keep the last answer rather than a transient query error.
+				if last == notQueried {
+					pending[ns] = err.Error()
+				}
+			case ok:
+				delete(pending, ns)
+			default:
+				pending[ns] = observed
+			}
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return &LagError{Record: record, Lagging: pending}
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check queries a single nameserver and reports whether it serves the
+// expected value, along with a description of what it served. An error is
+// returned when the nameserver could not be queried.
+func (c *Checker) Check(ctx context.Context, nameserver string, record Record) (bool, string, error) {
+	qtype, ok := dns.StringToType[strings.ToUpper(record.Type)]
+	if !ok {
+		return false, "", fmt.Errorf("unsupported record type %s", record.Type)
+	}
+
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(record.Name), qtype)
+	msg.RecursionDesired = false
+
+	in, _, err := c.client.ExchangeContext(ctx, msg, nameserver)
+	if err != nil {
+		return false, "", err
+	}
+	if in.Rcode != dns.RcodeSuccess {
+		return false, dns.RcodeToString[in.Rcode], nil
+	}
+
+	want := normalize(record.Type, record.Value)
+	var served []string
+	for _, rr := range in.Answer {
+		if rr.Header().Rrtype != qtype {
+			continue
+		}
+		value := rdata(rr)
+		if normalize(record.Type, value) == want {
+			return true, value, nil
+		}
+		served = append(served, value)
+	}
+
+	if len(served) == 0 {
+		return false, "no answer", nil
+	}
+	return false, strings.Join(served, ", "), nil
+}
+
+// rdata renders the data part of a resource record in the form Fornex uses
+// for record values.
+func rdata(rr dns.RR) string {
+	switch v := rr.(type) {
+	case *dns.A:
+		return v.A.String()
+	case *dns.AAAA:
+		return v.AAAA.String()
+	case *dns.CNAME:
+		return v.Target
+	case *dns.NS:
+		return v.Ns
+	case *dns.MX:
+		return v.Mx
+	case *dns.TXT:
+		return strings.Join(v.Txt, "")
+	case *dns.SRV:
+		return fmt.Sprintf("%d %d %s", v.Weight, v.Port, v.Target)
+	case *dns.CAA:
+		return fmt.Sprintf("%d %s %q", v.Flag, v.Tag, v.Value)
+	default:
+		return strings.TrimPrefix(rr.String(), rr.Header().String())
+	}
+}
+
+func normalize(typ, value string) string {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(typ, "TXT") {
+		return strings.Trim(value, `"`)
+	}
+
+	fields := strings.Fields(strings.ToLower(value))
+	for i, f := range fields {
+		fields[i] = strings.TrimSuffix(strings.Trim(f, `"`), ".")
+	}
+	return strings.Join(fields, " ")
+}
+
+// FQDN builds the fully qualified name of a record from its host part. A
+// host ending with a dot is taken as fully qualified; the API also reports
+// hosts as names within domain without the dot, e.g. "www.example.com" or
+// "example.com" for the apex.
+func FQDN(host, domain string) string {
+	domain = strings.TrimSuffix(domain, ".")
+
+	switch {
+	case strings.HasSuffix(host, "."):
+		return host
+	case host == "" || host == "@" || strings.EqualFold(host, domain):
+		return dns.Fqdn(domain)
+	case len(host) > len(domain) && strings.EqualFold(host[len(host)-len(domain)-1:], "."+domain):
+		return dns.Fqdn(host)
+	default:
+		return dns.Fqdn(host + "." + domain)
+	}
+}
+
+func withPort(addr string) string {
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+	return net.JoinHostPort(strings.Trim(addr, "[]"), DefaultPort)
+}
@@ -0,0 +1,140 @@
+package dnscheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// startServer runs a local DNS server answering with the records returned by
+// answer and returns its address.
+func startServer(t *testing.T, answer func(q dns.Question) []dns.RR) string {
+	t.Helper()
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	started := make(chan struct{})
+	server := &dns.Server{
+		PacketConn:        pc,
+		NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			m := new(dns.Msg)
+			m.SetReply(r)
+			m.Authoritative = true
+			m.Answer = answer(r.Question[0])
+			_ = w.WriteMsg(m)
+		}),
+	}
+	go func() {
+		_ = server.ActivateAndServe()
+	}()
+	<-started
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+
+	return pc.LocalAddr().String()
+}
+
+func TestWait(t *testing.T) {
+	var queries atomic.Int32
+	addr := startServer(t, func(q dns.Question) []dns.RR {
+		// Serve the new value from the third query on.
+		if queries.Add(1) < 3 {
+			return nil
+		}
+		rr, _ := dns.NewRR("www.example.com. 300 IN A 1.2.3.4")
+		return []dns.RR{rr}
+	})
+
+	checker := NewChecker([]string{addr}, 10*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := checker.Wait(ctx, Record{Name: FQDN("www", "example.com"), Type: "A", Value: "1.2.3.4"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if queries.Load() < 3 {
+		t.Errorf("Expected at least 3 queries, got: %d", queries.Load())
+	}
+}
+
+func TestWaitReportsLaggingNameservers(t *testing.T) {
+	good := startServer(t, func(q dns.Question) []dns.RR {
+		rr, _ := dns.NewRR(`example.com. 300 IN TXT "v=spf1 -all"`)
+		return []dns.RR{rr}
+	})
+	stale := startServer(t, func(q dns.Question) []dns.RR {
+		rr, _ := dns.NewRR(`example.com. 300 IN TXT "old"`)
+		return []dns.RR{rr}
+	})
+
+	checker := NewChecker([]string{good, stale}, 10*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	err := checker.Wait(ctx, Record{Name: FQDN("@", "example.com"), Type: "TXT", Value: "v=spf1 -all"})
+
+	var lagErr *LagError
+	if !errors.As(err, &lagErr) {
+		t.Fatalf("Expected *LagError, got: %v", err)
+	}
+	if len(lagErr.Lagging) != 1 || lagErr.Lagging[stale] != "old" {
+		t.Errorf("Expected only %s to lag, got: %v", stale, lagErr.Lagging)
+	}
+	if !strings.Contains(err.Error(), stale) {
+		t.Errorf("Expected error to mention %s, got: %s", stale, err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		typ, a, b string
+	}{
+		{"CNAME", "Target.Example.com.", "target.example.com"},
+		{"SRV", "10 5060 sip.example.com.", "10 5060 sip.example.com"},
+		{"CAA", `0 issue "letsencrypt.org"`, "0 issue letsencrypt.org"},
+		{"TXT", `"hello"`, "hello"},
+	}
+
+	for _, tt := range tests {
+		if normalize(tt.typ, tt.a) != normalize(tt.typ, tt.b) {
+			t.Errorf("Expected %q and %q to be equal for %s", tt.a, tt.b, tt.typ)
+		}
+	}
+
+	if normalize("TXT", "Hello") == normalize("TXT", "hello") {
+		t.Error("Expected TXT values to be case sensitive")
+	}
+}
+
+func TestFQDN(t *testing.T) {
+	tests := map[string]string{
+		"@":                "example.com.",
+		"":                 "example.com.",
+		"www":              "www.example.com.",
+		"mail.other.test.": "mail.other.test.",
+		"example.com":      "example.com.",
+		"Example.COM":      "example.com.",
+		"www.example.com":  "www.example.com.",
+		"a.b.example.com":  "a.b.example.com.",
+		"notexample.com":   "notexample.com.example.com.",
+		"www.example":      "www.example.example.com.",
+	}
+
+	for host, want := range tests {
+		if got := FQDN(host, "example.com"); got != want {
+			t.Errorf("FQDN(%q): expected %q, got %q", host, want, got)
+		}
+	}
+}
@@ -61,19 +61,22 @@ func (d *DomainDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	pd, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.client = pd.Client
 }
 
 func (d *DomainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.fornex_domain.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data DomainDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -82,7 +85,7 @@ func (d *DomainDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	domain, err := d.client.GetDomain(data.Name.ValueString())
+	domain, err := d.client.GetDomain(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to get domain, got error: %s", err))
 		return
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+var _ list.ListResource = &DomainListResource{}
+var _ list.ListResourceWithConfigure = &DomainListResource{}
+
+type DomainListResource struct {
+	client *client.Client
+}
+
+type DomainListResourceModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	Tags      types.List   `tfsdk:"tags"`
+}
+
+func NewDomainListResource() list.ListResource {
+	return &DomainListResource{}
+}
+
+func (r *DomainListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+func (r *DomainListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the domains of the Fornex account, e.g. to generate import configuration with `terraform query`.",
+		Attributes: map[string]listschema.Attribute{
+			"name_regex": listschema.StringAttribute{
+				Description: "Only list domains whose name matches this regular expression.",
+				Optional:    true,
+			},
+			"tags": listschema.ListAttribute{
+				Description: "Only list domains that have all of these tags.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *DomainListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = pd.Client
+}
+
+func (r *DomainListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config DomainListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !config.NameRegex.IsNull() {
+		re, err := regexp.Compile(config.NameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		nameRegex = re
+	}
+
+	var tags []string
+	if !config.Tags.IsNull() {
+		diags.Append(config.Tags.ElementsAs(ctx, &tags, false)...)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for domain, err := range r.client.Domains(ctx) {
+			if err != nil {
+				result := req.NewListResult(ctx)
+				result.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list domains, got error: %s", err))
+				push(result)
+				return
+			}
+
+			if nameRegex != nil && !nameRegex.MatchString(domain.Name) {
+				continue
+			}
+			if !domain.HasTags(tags) {
+				continue
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = domain.Name
+			result.Diagnostics.Append(result.Identity.Set(ctx, DomainIdentityModel{Name: types.StringValue(domain.Name)})...)
+
+			if req.IncludeResource {
+				data := DomainResourceModel{
+					Protection:   types.BoolValue(false),
+					ForceDestroy: types.BoolValue(false),
+					Timeouts:     nullTimeouts(),
+				}
+				result.Diagnostics.Append(setDomainData(ctx, &data, &domain)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+
+			count++
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+		}
+	}
+}
+
+// nullTimeouts returns an unset timeouts block for resources built outside of
+// a plan, such as list results.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
@@ -2,27 +2,65 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/mglants/terraform-provider-fornex/internal/client"
 )
 
 var _ resource.Resource = &DomainResource{}
 var _ resource.ResourceWithImportState = &DomainResource{}
+var _ resource.ResourceWithIdentity = &DomainResource{}
 
 type DomainResource struct {
-	client *client.Client
+	client           *client.Client
+	protectedRecords []recordPattern
 }
 
+// Modes for handling the records Fornex creates together with a new domain.
+const (
+	defaultRecordsKeep           = "keep"
+	defaultRecordsDeleteAll      = "delete_all"
+	defaultRecordsDeleteExceptNS = "delete_except_ns"
+)
+
+// privateDefaultRecordIDs is the private state key holding the IDs of the
+// records that existed right after the domain was created.
+const privateDefaultRecordIDs = "default_record_ids"
+
 type DomainResourceModel struct {
+	Name           types.String `tfsdk:"name"`
+	IP             types.String `tfsdk:"ip"`
+	Tags           types.Set    `tfsdk:"tags"`
+	DefaultRecords types.String `tfsdk:"default_records"`
+	Protection     types.Bool   `tfsdk:"deletion_protection"`
+	ForceDestroy   types.Bool   `tfsdk:"force_destroy"`
+	Created        types.String `tfsdk:"created"`
+	Updated        types.String `tfsdk:"updated"`
+	Nameservers    types.List   `tfsdk:"nameservers"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// DomainIdentityModel is the resource identity of a domain.
+type DomainIdentityModel struct {
 	Name types.String `tfsdk:"name"`
-	IP   types.String `tfsdk:"ip"`
 }
 
 func NewDomainResource() resource.Resource {
@@ -45,9 +83,92 @@ func (r *DomainResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"ip": schema.StringAttribute{
-				Description: "Initial IP address for the domain. This attribute is only used during creation and is not tracked in the state afterwards.",
+				Description: "Initial IP address for the domain, used by Fornex to create the default records. Required on creation; later changes are ignored and never produce a diff.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					createOnlyString(),
+				},
+			},
+			"tags": schema.SetAttribute{
+				Description: "Set of tags associated with the domain.",
+				ElementType: types.StringType,
 				Optional:    true,
 			},
+			"default_records": schema.StringAttribute{
+				Description: "What to do with the records Fornex creates for a new domain: `keep` leaves them in place, " +
+					"`delete_all` removes them all and `delete_except_ns` removes everything but NS records. " +
+					"Only applied on creation. Defaults to `keep`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(defaultRecordsKeep),
+				Validators: []validator.String{
+					stringvalidator.OneOf(defaultRecordsKeep, defaultRecordsDeleteAll, defaultRecordsDeleteExceptNS),
+				},
+				PlanModifiers: []planmodifier.String{
+					createOnlyString(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Whether the domain is protected from deletion. While enabled, destroying the domain fails; " +
+					"it must be set to `false` and applied before the domain can be deleted. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"force_destroy": schema.BoolAttribute{
+				Description: "Allow deleting the domain while it still contains records that are not managed by Terraform. " +
+					"Records Fornex created together with the domain are not considered. Imported domains, and domains created by " +
+					"provider versions before this check, are not checked because their default records are not known. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"created": schema.StringAttribute{
+				Description: "The date and time the domain was created.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated": schema.StringAttribute{
+				Description: "The date and time the domain was last updated. Only shown as changing in a plan when `tags` change.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					// Tags are the only property updated in place.
+					useStateUnlessChanged(path.Root("tags")),
+				},
+			},
+			"nameservers": schema.ListAttribute{
+				Description: "Authoritative nameservers the domain should be delegated to at the registrar, taken from the " +
+					"apex NS records of the zone. The API does not report the delegation otherwise, so a zone without " +
+					"apex NS records, e.g. after `default_records = \"delete_all\"`, reports the standard Fornex " +
+					"nameservers `ns1.fornex.com`, `ns2.fornex.com` and `ns3.fornex.com`.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *DomainResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				Description:       "The domain name.",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
@@ -57,19 +178,23 @@ func (r *DomainResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	pd, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = pd.Client
+	r.protectedRecords = pd.ProtectedRecords
 }
 
 func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "fornex_domain.Create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data DomainResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -78,24 +203,96 @@ func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	if data.IP.IsNull() {
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	if data.IP.IsNull() || data.IP.IsUnknown() {
 		resp.Diagnostics.AddError("Missing IP", "The ip attribute is required when creating a domain.")
 		return
 	}
 
-	domain, err := r.client.CreateDomain(data.Name.ValueString(), data.IP.ValueString())
+	domain, err := r.client.CreateDomain(ctx, data.Name.ValueString(), data.IP.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create domain, got error: %s", err))
 		return
 	}
 
-	data.Name = types.StringValue(domain.Name)
-	data.IP = types.StringNull()
+	// Save the domain right away, so a failure in one of the steps below
+	// leaves a tainted resource rather than a domain Terraform does not know.
+	plannedTags := data.Tags
+	resp.Diagnostics.Append(setDomainData(ctx, &data, domain)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainIdentityModel{Name: data.Name})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Remember the records Fornex created together with the domain, so Delete
+	// can tell them apart from records added outside of Terraform later on.
+	entries, err := r.client.ListEntries(ctx, domain.Name)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list domain records, got error: %s", err))
+		return
+	}
+	ids := make([]int, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	raw, err := json.Marshal(ids)
+	if err != nil {
+		resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to encode default record IDs: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, privateDefaultRecordIDs, raw)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Tags = plannedTags
+	if !data.Tags.IsNull() {
+		var tags []string
+		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		_, err = r.client.UpdateDomainTags(ctx, domain.Name, tags)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set domain tags, got error: %s", err))
+			return
+		}
+	}
+
+	if mode := data.DefaultRecords.ValueString(); mode != defaultRecordsKeep {
+		if err := r.purgeDefaultRecords(ctx, domain.Name, entries, mode); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete default records, got error: %s", err))
+			return
+		}
+	}
+
+	// Read the domain back so computed attributes reflect the server state.
+	domain, err = r.client.GetDomain(ctx, domain.Name)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(setDomainData(ctx, &data, domain)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainIdentityModel{Name: data.Name})...)
 }
 
 func (r *DomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "fornex_domain.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data DomainResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -104,23 +301,90 @@ func (r *DomainResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	domain, err := r.client.GetDomain(data.Name.ValueString())
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	domain, err := r.client.GetDomain(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
 		return
 	}
 
-	data.Name = types.StringValue(domain.Name)
-	data.IP = types.StringNull()
+	resp.Diagnostics.Append(setDomainData(ctx, &data, domain)...)
+
+	// Imported domains have no value for the provider-side settings yet.
+	if data.Protection.IsNull() {
+		data.Protection = types.BoolValue(false)
+	}
+	if data.ForceDestroy.IsNull() {
+		data.ForceDestroy = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainIdentityModel{Name: data.Name})...)
 }
 
 func (r *DomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Domain update is not supported by API (only tags).
+	ctx, span := startSpan(ctx, "fornex_domain.Update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var data, state DomainResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	// Tags are the only domain property the API allows to change in place.
+	if !data.Tags.Equal(state.Tags) {
+		var tags []string
+		if !data.Tags.IsNull() {
+			resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
+		_, err := r.client.UpdateDomainTags(ctx, data.Name.ValueString(), tags)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update domain tags, got error: %s", err))
+			return
+		}
+	}
+
+	domain, err := r.client.GetDomain(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(setDomainData(ctx, &data, domain)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, DomainIdentityModel{Name: data.Name})...)
 }
 
 func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "fornex_domain.Delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data DomainResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -129,7 +393,84 @@ func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteDomain(data.Name.ValueString())
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if data.Protection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Domain Deletion Protected",
+			fmt.Sprintf("Domain %s has deletion_protection enabled. Set deletion_protection = false and apply "+
+				"before destroying it.", data.Name.ValueString()),
+		)
+		return
+	}
+
+	entries, err := r.client.ListEntries(ctx, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list domain records, got error: %s", err))
+		return
+	}
+
+	var protected []client.Entry
+	for _, e := range entries {
+		if _, ok := protectedBy(r.protectedRecords, data.Name.ValueString(), e.Host, e.Type); ok {
+			protected = append(protected, e)
+		}
+	}
+	if len(protected) > 0 {
+		resp.Diagnostics.AddError(
+			"Domain Contains Protected Records",
+			fmt.Sprintf("Domain %s contains records matching the provider protected_records patterns:\n%s",
+				data.Name.ValueString(), describeEntries(protected)),
+		)
+		return
+	}
+
+	raw, diags := req.Private.GetKey(ctx, privateDefaultRecordIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Without the records present right after creation, e.g. for imported
+	// domains or those created by earlier provider versions, Fornex's default
+	// records cannot be told apart, so nothing is considered unmanaged.
+	if !data.ForceDestroy.ValueBool() && raw != nil {
+		var ids []int
+		if err := json.Unmarshal(raw, &ids); err != nil {
+			resp.Diagnostics.AddError("Internal Error", fmt.Sprintf("Unable to decode default record IDs: %s", err))
+			return
+		}
+		defaults := map[int]bool{}
+		for _, id := range ids {
+			defaults[id] = true
+		}
+
+		var unmanaged []client.Entry
+		for _, e := range entries {
+			if defaults[e.ID] || strings.EqualFold(e.Type, "SOA") || strings.EqualFold(e.Type, "NS") {
+				continue
+			}
+			unmanaged = append(unmanaged, e)
+		}
+		if len(unmanaged) > 0 {
+			resp.Diagnostics.AddError(
+				"Domain Contains Unmanaged Records",
+				fmt.Sprintf("Domain %s still contains records that are not managed by this configuration:\n%s\n\n"+
+					"Remove them, or set force_destroy = true and apply before destroying the domain.",
+					data.Name.ValueString(), describeEntries(unmanaged)),
+			)
+			return
+		}
+	}
+
+	err = r.client.DeleteDomain(ctx, data.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete domain, got error: %s", err))
 		return
@@ -137,5 +478,61 @@ func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *DomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("name"), path.Root("name"), req, resp)
+}
+
+// purgeDefaultRecords deletes the records Fornex populated a freshly created
+// domain with, according to the default_records mode.
+func (r *DomainResource) purgeDefaultRecords(ctx context.Context, domainName string, entries []client.Entry, mode string) error {
+	for _, e := range entries {
+		if strings.EqualFold(e.Type, "SOA") {
+			continue
+		}
+		if mode == defaultRecordsDeleteExceptNS && strings.EqualFold(e.Type, "NS") {
+			continue
+		}
+		if err := r.client.DeleteEntry(ctx, domainName, e.ID); err != nil {
+			return fmt.Errorf("deleting %s record %q (%d): %w", e.Type, e.Host, e.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// setDomainData copies the attributes returned by the API into the model.
+func setDomainData(ctx context.Context, data *DomainResourceModel, domain *client.Domain) diag.Diagnostics {
+	data.Name = types.StringValue(domain.Name)
+	data.Created = types.StringValue(domain.Created)
+	data.Updated = types.StringValue(domain.Updated)
+
+	nameservers, diags := types.ListValueFrom(ctx, types.StringType, domain.Nameservers())
+	if diags.HasError() {
+		return diags
+	}
+	data.Nameservers = nameservers
+
+	diags.Append(setDomainTags(ctx, data, domain.Tags)...)
+	return diags
+}
+
+// setDomainTags stores the tags returned by the API in the model. An empty tag
+// list keeps a null value null so that configurations without tags produce no
+// diff.
+func setDomainTags(ctx context.Context, data *DomainResourceModel, tags []string) diag.Diagnostics {
+	if len(tags) == 0 {
+		if data.Tags.IsNull() || data.Tags.IsUnknown() {
+			data.Tags = types.SetNull(types.StringType)
+		} else {
+			data.Tags = types.SetValueMust(types.StringType, []attr.Value{})
+		}
+		return nil
+	}
+
+	value, diags := types.SetValueFrom(ctx, types.StringType, tags)
+	if diags.HasError() {
+		return diags
+	}
+
+	data.Tags = value
+	return diags
 }
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDomainCreateSavesStateOnLaterFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"name": "example.com", "created": "2024-01-01T00:00:00Z"}`))
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			// Setting the tags fails after the domain was created.
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	plan := domainValue(t, map[string]tftypes.Value{
+		"name":                tftypes.NewValue(tftypes.String, "example.com"),
+		"ip":                  tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		"tags":                tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "prod")}),
+		"default_records":     tftypes.NewValue(tftypes.String, defaultRecordsKeep),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		"force_destroy":       tftypes.NewValue(tftypes.Bool, false),
+		"created":             tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"updated":             tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"nameservers":         tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue),
+	})
+
+	provider := testProviderServer(t, map[string]tftypes.Value{"base_url": tftypes.NewValue(tftypes.String, server.URL)})
+	state, diags := createResource(t, provider, "fornex_domain", plan)
+
+	if got := errorSummaries(diags); !slices.Equal(got, []string{"Client Error"}) {
+		t.Fatalf("Expected the tag update to fail, got: %q", got)
+	}
+	for _, d := range diags {
+		if !strings.Contains(d.Detail, "Unable to set domain tags") {
+			t.Errorf("Expected the tag update to fail, got: %s", d.Detail)
+		}
+	}
+
+	// Terraform taints the saved domain instead of losing track of it.
+	if !state.IsFullyKnown() {
+		t.Errorf("Expected no unknown values in state, got: %s", state)
+	}
+	if name := plannedString(t, state, "name"); name == nil || *name != "example.com" {
+		t.Errorf("Expected the created domain in state, got: %s", state)
+	}
+	if created := plannedString(t, state, "created"); created == nil || *created != "2024-01-01T00:00:00Z" {
+		t.Errorf("Expected the created date in state, got: %s", state)
+	}
+}
+
+func TestDomainCreateDefaultRecords(t *testing.T) {
+	tests := map[string][]string{
+		defaultRecordsKeep:           nil,
+		defaultRecordsDeleteAll:      {"/dns/domain/example.com/entry_set/2/", "/dns/domain/example.com/entry_set/3/", "/dns/domain/example.com/entry_set/4/"},
+		defaultRecordsDeleteExceptNS: {"/dns/domain/example.com/entry_set/3/", "/dns/domain/example.com/entry_set/4/"},
+	}
+
+	for mode, expected := range tests {
+		var deletes []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case r.Method == http.MethodDelete:
+				deletes = append(deletes, r.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			case strings.HasSuffix(r.URL.Path, "/entry_set/"):
+				// The SOA record is never deleted.
+				_, _ = w.Write([]byte(testZoneEntries))
+			default:
+				_, _ = w.Write([]byte(`{"name": "example.com", "created": "2024-01-01T00:00:00Z"}`))
+			}
+		}))
+
+		provider := testProviderServer(t, map[string]tftypes.Value{"base_url": tftypes.NewValue(tftypes.String, server.URL)})
+		_, diags := createResource(t, provider, "fornex_domain", domainValue(t, map[string]tftypes.Value{
+			"name":                tftypes.NewValue(tftypes.String, "example.com"),
+			"ip":                  tftypes.NewValue(tftypes.String, "192.0.2.1"),
+			"default_records":     tftypes.NewValue(tftypes.String, mode),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+			"force_destroy":       tftypes.NewValue(tftypes.Bool, false),
+			"created":             tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"updated":             tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"nameservers":         tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tftypes.UnknownValue),
+		}))
+		server.Close()
+
+		if got := errorSummaries(diags); len(got) != 0 {
+			t.Fatalf("%s: expected no error, got: %v", mode, diags)
+		}
+		if !slices.Equal(deletes, expected) {
+			t.Errorf("%s: expected deletes %q, got: %q", mode, expected, deletes)
+		}
+	}
+}
@@ -73,22 +73,25 @@ func (d *DomainsDataSource) Configure(ctx context.Context, req datasource.Config
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	pd, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.client = pd.Client
 }
 
 func (d *DomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.fornex_domains.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data DomainsDataSourceModel
 
-	domains, err := d.client.ListDomains()
+	domains, err := d.client.ListDomains(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list domains, got error: %s", err))
 		return
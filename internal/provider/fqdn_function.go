@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/mglants/terraform-provider-fornex/internal/dnscheck"
+)
+
+var _ function.Function = &FQDNFunction{}
+
+type FQDNFunction struct{}
+
+func NewFQDNFunction() function.Function {
+	return &FQDNFunction{}
+}
+
+func (f *FQDNFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "fqdn"
+}
+
+func (f *FQDNFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the fully qualified name of a record",
+		MarkdownDescription: "Returns the fully qualified, dot-terminated name of `host` in `domain`. " +
+			"An empty host or `@` is the domain apex, a host that already ends with a dot is returned unchanged, " +
+			"and a name within `domain` without the trailing dot, e.g. `www.example.com`, is not qualified twice.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "host",
+				MarkdownDescription: "Host part of the record, e.g. `www`, `@` or `_dmarc`.",
+			},
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "Domain name, e.g. `example.com`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FQDNFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var host, domain string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &host, &domain))
+	if resp.Error != nil {
+		return
+	}
+
+	domain = strings.TrimSuffix(strings.TrimSpace(domain), ".")
+	if domain == "" {
+		resp.Error = function.NewArgumentFuncError(1, "domain must not be empty")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, dnscheck.FQDN(strings.TrimSpace(host), domain)))
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction calls f with args and returns its result and error.
+func runFunction(t *testing.T, f function.Function, result attr.Value, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func runStringFunction(t *testing.T, f function.Function, args ...attr.Value) (string, *function.FuncError) {
+	t.Helper()
+
+	value, err := runFunction(t, f, types.StringUnknown(), args...)
+	return value.(types.String).ValueString(), err
+}
+
+func TestFQDNFunction(t *testing.T) {
+	tests := []struct {
+		host, domain, want string
+	}{
+		{"www", "example.com", "www.example.com."},
+		{"@", "example.com.", "example.com."},
+		{"", "example.com", "example.com."},
+		{"_dmarc.mail", "example.com", "_dmarc.mail.example.com."},
+		{"other.example.net.", "example.com", "other.example.net."},
+		{"example.com", "example.com.", "example.com."},
+		{"www.example.com", "example.com", "www.example.com."},
+	}
+
+	for _, tt := range tests {
+		got, err := runStringFunction(t, NewFQDNFunction(), types.StringValue(tt.host), types.StringValue(tt.domain))
+		if err != nil {
+			t.Fatalf("fqdn(%q, %q): expected no error, got: %s", tt.host, tt.domain, err)
+		}
+		if got != tt.want {
+			t.Errorf("fqdn(%q, %q): expected %q, got %q", tt.host, tt.domain, tt.want, got)
+		}
+	}
+
+	if _, err := runStringFunction(t, NewFQDNFunction(), types.StringValue("www"), types.StringValue("")); err == nil {
+		t.Error("Expected error for empty domain, got nil")
+	}
+}
+
+func TestRelativeHostFunction(t *testing.T) {
+	tests := []struct {
+		fqdn, domain, want string
+	}{
+		{"www.example.com.", "example.com", "www"},
+		{"www.example.com", "example.com.", "www"},
+		{"a.b.Example.COM", "example.com", "a.b"},
+		{"example.com.", "example.com", "@"},
+	}
+
+	for _, tt := range tests {
+		got, err := runStringFunction(t, NewRelativeHostFunction(), types.StringValue(tt.fqdn), types.StringValue(tt.domain))
+		if err != nil {
+			t.Fatalf("relative_host(%q, %q): expected no error, got: %s", tt.fqdn, tt.domain, err)
+		}
+		if got != tt.want {
+			t.Errorf("relative_host(%q, %q): expected %q, got %q", tt.fqdn, tt.domain, tt.want, got)
+		}
+	}
+
+	for _, fqdn := range []string{"www.example.net", "wwwexample.com", ".example.com"} {
+		if _, err := runStringFunction(t, NewRelativeHostFunction(), types.StringValue(fqdn), types.StringValue("example.com")); err == nil {
+			t.Errorf("relative_host(%q): expected error, got nil", fqdn)
+		}
+	}
+}
+
+func TestSplitTXTFunction(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	value, err := runFunction(t, NewSplitTXTFunction(), types.ListUnknown(types.StringType), types.StringValue(long))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	var chunks []string
+	value.(types.List).ElementsAs(context.Background(), &chunks, false)
+	if len(chunks) != 2 || len(chunks[0]) != 255 || len(chunks[1]) != 45 {
+		t.Errorf("Expected chunks of 255 and 45 bytes, got: %d chunks", len(chunks))
+	}
+
+	short := splitTXT("v=spf1 -all")
+	if len(short) != 1 || short[0] != "v=spf1 -all" {
+		t.Errorf("Expected short value unchanged, got: %q", short)
+	}
+
+	// A two byte character straddling the 255 byte boundary moves to the
+	// next chunk.
+	multiByte := splitTXT(strings.Repeat("a", 254) + "é" + "b")
+	if len(multiByte) != 2 || multiByte[0] != strings.Repeat("a", 254) || multiByte[1] != "éb" {
+		t.Errorf("Expected multi-byte character to stay intact, got: %q", multiByte)
+	}
+
+	if empty := splitTXT(""); len(empty) != 1 || empty[0] != "" {
+		t.Errorf("Expected a single empty string, got: %q", empty)
+	}
+}
+
+func TestReversePTRNameFunction(t *testing.T) {
+	tests := []struct {
+		ip, want string
+	}{
+		{"192.0.2.10", "10.2.0.192.in-addr.arpa."},
+		{"::ffff:192.0.2.10", "10.2.0.192.in-addr.arpa."},
+		{"2001:db8::1", "1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."},
+	}
+
+	for _, tt := range tests {
+		got, err := runStringFunction(t, NewReversePTRNameFunction(), types.StringValue(tt.ip))
+		if err != nil {
+			t.Fatalf("reverse_ptr_name(%q): expected no error, got: %s", tt.ip, err)
+		}
+		if got != tt.want {
+			t.Errorf("reverse_ptr_name(%q): expected %q, got %q", tt.ip, tt.want, got)
+		}
+	}
+
+	if _, err := runStringFunction(t, NewReversePTRNameFunction(), types.StringValue("not-an-ip")); err == nil {
+		t.Error("Expected error for invalid IP, got nil")
+	}
+}
+
+func TestSRVValueFunction(t *testing.T) {
+	got, err := runStringFunction(t, NewSRVValueFunction(), types.Int64Value(10), types.Int64Value(5060), types.StringValue("sip.example.com"))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if got != "10 5060 sip.example.com." {
+		t.Errorf("Unexpected SRV value: %q", got)
+	}
+
+	got, err = runStringFunction(t, NewSRVValueFunction(), types.Int64Value(0), types.Int64Value(0), types.StringValue("."))
+	if err != nil || got != "0 0 ." {
+		t.Errorf("Expected \"0 0 .\", got: %q, %v", got, err)
+	}
+
+	_, err = runStringFunction(t, NewSRVValueFunction(), types.Int64Value(1), types.Int64Value(70000), types.StringValue("sip.example.com"))
+	if err == nil || err.FunctionArgument == nil || *err.FunctionArgument != 1 {
+		t.Errorf("Expected error for the port argument, got: %v", err)
+	}
+}
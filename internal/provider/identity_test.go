@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// importRecord runs the record ImportState with either an import ID or an
+// identity and returns the imported state.
+func importRecord(t *testing.T, id string, identity *RecordIdentityModel) RecordResourceModel {
+	t.Helper()
+
+	ctx := context.Background()
+	r := &RecordResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	req := resource.ImportStateRequest{ID: id}
+	if identity != nil {
+		req.Identity = &tfsdk.ResourceIdentity{
+			Schema: identityResp.IdentitySchema,
+			Raw:    tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), nil),
+		}
+		if diags := req.Identity.Set(ctx, identity); diags.HasError() {
+			t.Fatalf("Expected no error, got: %v", diags)
+		}
+	}
+
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.ImportState(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got: %v", resp.Diagnostics)
+	}
+
+	var data RecordResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("Expected no error, got: %v", diags)
+	}
+	return data
+}
+
+func TestRecordImportState(t *testing.T) {
+	byID := importRecord(t, "example.com/42", nil)
+	byIdentity := importRecord(t, "", &RecordIdentityModel{
+		DomainName: types.StringValue("example.com"),
+		RecordID:   types.Int64Value(42),
+	})
+
+	for name, data := range map[string]RecordResourceModel{"id": byID, "identity": byIdentity} {
+		if data.ID.ValueString() != "example.com/42" || data.DomainName.ValueString() != "example.com" || data.RecordID.ValueInt64() != 42 {
+			t.Errorf("Import by %s: unexpected state: id %s, domain_name %s, record_id %s", name, data.ID, data.DomainName, data.RecordID)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+func TestRecordListResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[
+			{"id": 1, "host": "www", "type": "A", "value": "192.0.2.1", "ttl": 300},
+			{"id": 2, "host": "www", "type": "TXT", "value": "hello"},
+			{"id": 3, "host": "mail", "type": "A", "value": "192.0.2.2"},
+			{"id": 4, "host": "www", "type": "SOA", "value": "ns1.fornex.com. hostmaster.fornex.com. 1 7200 3600 1209600 3600"}
+		]`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	lr := &RecordListResource{client: client.NewClient("test-key", server.URL)}
+	rr := &RecordResource{}
+
+	configResp := &list.ListResourceSchemaResponse{}
+	lr.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, configResp)
+	schemaResp := &resource.SchemaResponse{}
+	rr.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	rr.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	config := tfsdk.Config{
+		Schema: configResp.Schema,
+		Raw: tftypes.NewValue(configResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"domain_name": tftypes.NewValue(tftypes.String, "example.com"),
+			"host":        tftypes.NewValue(tftypes.String, "WWW"),
+			"type":        tftypes.NewValue(tftypes.String, nil),
+		}),
+	}
+
+	stream := &list.ListResultsStream{}
+	lr.List(ctx, list.ListRequest{
+		Config:                 config,
+		IncludeResource:        true,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}, stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("Expected no error, got: %v", result.Diagnostics)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 supported records of host www, got: %d", len(results))
+	}
+
+	var identity RecordIdentityModel
+	results[0].Identity.Get(ctx, &identity)
+	if identity.DomainName.ValueString() != "example.com" || identity.RecordID.ValueInt64() != 1 {
+		t.Errorf("Unexpected identity: %+v", identity)
+	}
+
+	var data RecordResourceModel
+	results[0].Resource.Get(ctx, &data)
+	if data.ID.ValueString() != "example.com/1" || data.TTL.ValueInt64() != 300 {
+		t.Errorf("Unexpected resource: id %s, ttl %s", data.ID, data.TTL)
+	}
+	results[1].Resource.Get(ctx, &data)
+	if !data.TTL.Equal(types.Int64Null()) {
+		t.Errorf("Expected null ttl, got: %s", data.TTL)
+	}
+	if results[1].DisplayName != "www TXT hello" {
+		t.Errorf("Unexpected display name: %q", results[1].DisplayName)
+	}
+}
+
+func TestDomainListResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[
+			{"name": "example.com", "created": "2024-01-01T00:00:00Z", "tags": ["prod", "web"],
+			 "entry_set": [{"host": "@", "type": "NS", "value": "ns1.example.net."}]},
+			{"name": "example.net", "created": "2024-01-02T00:00:00Z", "tags": ["prod"]},
+			{"name": "staging.example.com", "created": "2024-01-03T00:00:00Z", "tags": ["web"]}
+		]`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	lr := &DomainListResource{client: client.NewClient("test-key", server.URL)}
+	dr := &DomainResource{}
+
+	configResp := &list.ListResourceSchemaResponse{}
+	lr.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, configResp)
+	schemaResp := &resource.SchemaResponse{}
+	dr.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	identityResp := &resource.IdentitySchemaResponse{}
+	dr.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, identityResp)
+
+	listDomains := func(nameRegex any, tags []string, includeResource bool) []list.ListResult {
+		tagValues := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil)
+		if tags != nil {
+			var values []tftypes.Value
+			for _, tag := range tags {
+				values = append(values, tftypes.NewValue(tftypes.String, tag))
+			}
+			tagValues = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+		}
+
+		stream := &list.ListResultsStream{}
+		lr.List(ctx, list.ListRequest{
+			Config: tfsdk.Config{
+				Schema: configResp.Schema,
+				Raw: tftypes.NewValue(configResp.Schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+					"name_regex": tftypes.NewValue(tftypes.String, nameRegex),
+					"tags":       tagValues,
+				}),
+			},
+			IncludeResource:        includeResource,
+			ResourceSchema:         schemaResp.Schema,
+			ResourceIdentitySchema: identityResp.IdentitySchema,
+		}, stream)
+
+		var results []list.ListResult
+		for result := range stream.Results {
+			if result.Diagnostics.HasError() {
+				t.Fatalf("Expected no error, got: %v", result.Diagnostics)
+			}
+			results = append(results, result)
+		}
+		return results
+	}
+
+	names := func(results []list.ListResult) []string {
+		var names []string
+		for _, result := range results {
+			var identity DomainIdentityModel
+			result.Identity.Get(ctx, &identity)
+			names = append(names, identity.Name.ValueString())
+		}
+		return names
+	}
+
+	tests := []struct {
+		name      string
+		nameRegex any
+		tags      []string
+		expected  []string
+	}{
+		{"all", nil, nil, []string{"example.com", "example.net", "staging.example.com"}},
+		{"name_regex", `\.com$`, nil, []string{"example.com", "staging.example.com"}},
+		{"tags", nil, []string{"prod"}, []string{"example.com", "example.net"}},
+		{"all tags", nil, []string{"web", "prod"}, []string{"example.com"}},
+		{"name_regex and tags", `^staging\.`, []string{"prod"}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := names(listDomains(tt.nameRegex, tt.tags, false)); !slices.Equal(got, tt.expected) {
+			t.Errorf("%s: expected %q, got: %q", tt.name, tt.expected, got)
+		}
+	}
+
+	results := listDomains(`^example\.com$`, nil, true)
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 domain, got: %d", len(results))
+	}
+	if results[0].DisplayName != "example.com" {
+		t.Errorf("Unexpected display name: %q", results[0].DisplayName)
+	}
+
+	var data DomainResourceModel
+	if diags := results[0].Resource.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("Expected no error, got: %v", diags)
+	}
+	if data.Name.ValueString() != "example.com" || data.Created.ValueString() != "2024-01-01T00:00:00Z" {
+		t.Errorf("Unexpected resource: name %s, created %s", data.Name, data.Created)
+	}
+	var tags, nameservers []string
+	data.Tags.ElementsAs(ctx, &tags, false)
+	data.Nameservers.ElementsAs(ctx, &nameservers, false)
+	if !slices.Equal(tags, []string{"prod", "web"}) || !slices.Equal(nameservers, []string{"ns1.example.net"}) {
+		t.Errorf("Unexpected resource: tags %q, nameservers %q", tags, nameservers)
+	}
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+)
+
+// createOnlyString returns a plan modifier for attributes that are only sent to
+// the API when the resource is created. Once the resource exists the prior
+// state value is planned, so configuration changes never produce a diff.
+// Resources without a prior value, e.g. imported or created by an earlier
+// provider version, plan the configuration value instead, which Update stores
+// without sending it to the API.
+func createOnlyString() planmodifier.String {
+	return createOnlyStringModifier{}
+}
+
+type createOnlyStringModifier struct{}
+
+func (m createOnlyStringModifier) Description(ctx context.Context) string {
+	return "Value is only used on creation; later changes are ignored."
+}
+
+func (m createOnlyStringModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m createOnlyStringModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Resource is being created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Terraform only accepts a planned value that differs from the
+	// configuration when there is a prior value to keep.
+	if req.StateValue.IsNull() {
+		if req.ConfigValue.IsNull() {
+			resp.PlanValue = req.StateValue
+		} else {
+			resp.PlanValue = req.ConfigValue
+		}
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !req.ConfigValue.Equal(req.StateValue) {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Create-Only Attribute Changed",
+			"The "+req.Path.String()+" attribute is only used when the resource is created. "+
+				"The new value is ignored; recreate the resource to apply it.",
+		)
+	}
+}
+
+// useStateUnlessChanged returns a plan modifier that plans the prior state
+// value of a computed attribute, like UseStateForUnknown, as long as none of
+// the attributes at paths change. A change of one of them may change the
+// attribute on the server too, so it is left unknown then.
+func useStateUnlessChanged(paths ...path.Path) planmodifier.String {
+	return useStateUnlessChangedModifier{paths: paths}
+}
+
+type useStateUnlessChangedModifier struct {
+	paths []path.Path
+}
+
+func (m useStateUnlessChangedModifier) Description(ctx context.Context) string {
+	return "Value does not change unless one of the attributes it depends on changes."
+}
+
+func (m useStateUnlessChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateUnlessChangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Resource is being created or destroyed, or the value is known already.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || !req.PlanValue.IsUnknown() || req.StateValue.IsNull() {
+		return
+	}
+
+	for _, p := range m.paths {
+		var planned, prior attr.Value
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !planned.Equal(prior) {
+			return
+		}
+	}
+
+	resp.PlanValue = req.StateValue
+}
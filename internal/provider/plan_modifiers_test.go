@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCreateOnlyStringWithoutPriorValue(t *testing.T) {
+	// State of an imported domain, or one created by a provider version that
+	// did not store ip and default_records.
+	prior := domainValue(t, map[string]tftypes.Value{
+		"name":                tftypes.NewValue(tftypes.String, "example.com"),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		"force_destroy":       tftypes.NewValue(tftypes.Bool, false),
+		"created":             tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00Z"),
+	})
+	config := domainValue(t, map[string]tftypes.Value{
+		"name":            tftypes.NewValue(tftypes.String, "example.com"),
+		"ip":              tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		"default_records": tftypes.NewValue(tftypes.String, defaultRecordsDeleteAll),
+	})
+	proposed := domainValue(t, map[string]tftypes.Value{
+		"name":                tftypes.NewValue(tftypes.String, "example.com"),
+		"ip":                  tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		"default_records":     tftypes.NewValue(tftypes.String, defaultRecordsDeleteAll),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		"force_destroy":       tftypes.NewValue(tftypes.Bool, false),
+		"created":             tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00Z"),
+	})
+
+	planned, _ := planDomain(t, prior, config, proposed)
+
+	// Terraform rejects plans that differ from a non-null configuration
+	// value when the prior value is null.
+	if ip := plannedString(t, planned, "ip"); ip == nil || *ip != "192.0.2.1" {
+		t.Errorf("Expected planned ip to match the configuration, got: %v", ip)
+	}
+	if mode := plannedString(t, planned, "default_records"); mode == nil || *mode != defaultRecordsDeleteAll {
+		t.Errorf("Expected planned default_records to match the configuration, got: %v", mode)
+	}
+}
+
+func TestCreateOnlyStringKeepsPriorValue(t *testing.T) {
+	prior := domainValue(t, map[string]tftypes.Value{
+		"name":                tftypes.NewValue(tftypes.String, "example.com"),
+		"ip":                  tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		"default_records":     tftypes.NewValue(tftypes.String, defaultRecordsKeep),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		"force_destroy":       tftypes.NewValue(tftypes.Bool, false),
+	})
+	config := domainValue(t, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "example.com"),
+		"ip":   tftypes.NewValue(tftypes.String, "192.0.2.2"),
+	})
+	proposed := domainValue(t, map[string]tftypes.Value{
+		"name":                tftypes.NewValue(tftypes.String, "example.com"),
+		"ip":                  tftypes.NewValue(tftypes.String, "192.0.2.2"),
+		"default_records":     tftypes.NewValue(tftypes.String, defaultRecordsKeep),
+		"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+		"force_destroy":       tftypes.NewValue(tftypes.Bool, false),
+	})
+
+	planned, diags := planDomain(t, prior, config, proposed)
+
+	if ip := plannedString(t, planned, "ip"); ip == nil || *ip != "192.0.2.1" {
+		t.Errorf("Expected planned ip to keep the prior value, got: %v", ip)
+	}
+	if len(diags) != 1 || diags[0].Severity != tfprotov6.DiagnosticSeverityWarning {
+		t.Errorf("Expected a create-only warning, got: %v", diags)
+	}
+}
+
+func TestDomainUpdatedPlan(t *testing.T) {
+	prod := tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "prod")})
+	attrs := func(extra map[string]tftypes.Value) map[string]tftypes.Value {
+		values := map[string]tftypes.Value{
+			"name":                tftypes.NewValue(tftypes.String, "example.com"),
+			"ip":                  tftypes.NewValue(tftypes.String, "192.0.2.1"),
+			"default_records":     tftypes.NewValue(tftypes.String, defaultRecordsKeep),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+			"force_destroy":       tftypes.NewValue(tftypes.Bool, false),
+			"created":             tftypes.NewValue(tftypes.String, "2024-01-01T00:00:00Z"),
+			"updated":             tftypes.NewValue(tftypes.String, "2024-02-01T00:00:00Z"),
+		}
+		for name, v := range extra {
+			values[name] = v
+		}
+		return values
+	}
+	prior := domainValue(t, attrs(nil))
+
+	// Changing a provider-side setting does not touch the domain.
+	config := domainValue(t, map[string]tftypes.Value{
+		"name":          tftypes.NewValue(tftypes.String, "example.com"),
+		"ip":            tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		"force_destroy": tftypes.NewValue(tftypes.Bool, true),
+	})
+	planned, _ := planDomain(t, prior, config, domainValue(t, attrs(map[string]tftypes.Value{
+		"force_destroy": tftypes.NewValue(tftypes.Bool, true),
+	})))
+	if updated := plannedString(t, planned, "updated"); updated == nil || *updated != "2024-02-01T00:00:00Z" {
+		t.Errorf("Expected updated to keep its prior value, got: %v", updated)
+	}
+
+	// Changing the tags updates the domain on the server.
+	config = domainValue(t, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, "example.com"),
+		"ip":   tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		"tags": prod,
+	})
+	planned, _ = planDomain(t, prior, config, domainValue(t, attrs(map[string]tftypes.Value{"tags": prod})))
+
+	var values map[string]tftypes.Value
+	if err := planned.As(&values); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if values["updated"].IsKnown() {
+		t.Errorf("Expected updated to be unknown after a tag change, got: %s", values["updated"])
+	}
+}
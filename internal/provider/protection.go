@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+// recordPattern matches records by host glob and, optionally, record type.
+type recordPattern struct {
+	raw  string
+	host string
+	typ  string
+}
+
+// parseRecordPattern parses a protected record pattern of the form "host" or
+// "host/TYPE", where host is a glob as understood by path.Match, relative to
+// the domain and with "@" for the apex.
+func parseRecordPattern(s string) (recordPattern, error) {
+	host, typ, _ := strings.Cut(s, "/")
+	if host == "" {
+		return recordPattern{}, fmt.Errorf("pattern %q has an empty host part", s)
+	}
+	if _, err := path.Match(host, ""); err != nil {
+		return recordPattern{}, fmt.Errorf("pattern %q is not a valid glob: %s", s, err)
+	}
+
+	return recordPattern{raw: s, host: strings.ToLower(host), typ: strings.ToUpper(typ)}, nil
+}
+
+// matches reports whether the record matches; host must be canonical, see
+// client.CanonicalHost.
+func (p recordPattern) matches(host, typ string) bool {
+	if p.typ != "" && !strings.EqualFold(p.typ, typ) {
+		return false
+	}
+	ok, _ := path.Match(p.host, host)
+	return ok
+}
+
+// protectedBy returns the first pattern matching the given record of domain,
+// if any. The apex matches "@" however the API spells it.
+func protectedBy(patterns []recordPattern, domain, host, typ string) (recordPattern, bool) {
+	host = client.CanonicalHost(host, domain)
+	for _, p := range patterns {
+		if p.matches(host, typ) {
+			return p, true
+		}
+	}
+	return recordPattern{}, false
+}
+
+// describeEntries formats entries for diagnostics, truncating long lists.
+func describeEntries(entries []client.Entry) string {
+	const limit = 10
+
+	var lines []string
+	for i, e := range entries {
+		if i == limit {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(entries)-limit))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  - %s %s %s (id %d)", e.Host, e.Type, e.Value, e.ID))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseRecordPattern(t *testing.T) {
+	if _, err := parseRecordPattern("/MX"); err == nil {
+		t.Error("Expected error for empty host part, got nil")
+	}
+	if _, err := parseRecordPattern("[/MX"); err == nil {
+		t.Error("Expected error for invalid glob, got nil")
+	}
+
+	tests := []struct {
+		pattern, host, typ string
+		want               bool
+	}{
+		{"@/MX", "@", "mx", true},
+		{"@/MX", "", "MX", true},
+		{"@/MX", "example.com.", "MX", true},
+		{"@/MX", "www", "MX", false},
+		{"@/MX", "@", "TXT", false},
+		{"_dmarc", "_DMARC", "TXT", true},
+		{"*/NS", "sub.example.com", "NS", true},
+		{"*/NS", "sub", "A", false},
+	}
+	for _, tt := range tests {
+		p, err := parseRecordPattern(tt.pattern)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %s", tt.pattern, err)
+		}
+		if _, got := protectedBy([]recordPattern{p}, "example.com", tt.host, tt.typ); got != tt.want {
+			t.Errorf("%s: expected %v for %q %s, got %v", tt.pattern, tt.want, tt.host, tt.typ, got)
+		}
+	}
+}
+
+// fakeZoneAPI serves the given entries for every domain and records the
+// DELETE requests it receives.
+func fakeZoneAPI(t *testing.T, entries string) (*httptest.Server, *[]string) {
+	var deletes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(entries))
+		case http.MethodDelete:
+			deletes = append(deletes, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("Unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &deletes
+}
+
+const testZoneEntries = `[
+	{"id": 1, "host": "example.com", "type": "SOA", "value": "ns1.fornex.com. hostmaster.fornex.com. 1 3600 600 86400 3600"},
+	{"id": 2, "host": "@", "type": "NS", "value": "ns1.fornex.com"},
+	{"id": 3, "host": "", "type": "MX", "value": "mail.example.com", "priority": 10},
+	{"id": 4, "host": "www", "type": "A", "value": "192.0.2.1"}
+]`
+
+func defaultRecordsPrivate(t *testing.T, ids ...int) []byte {
+	t.Helper()
+
+	raw, err := json.Marshal(ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	private, err := json.Marshal(map[string][]byte{privateDefaultRecordIDs: raw})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return private
+}
+
+func TestDomainDelete(t *testing.T) {
+	domain := func(protection, forceDestroy bool) tftypes.Value {
+		return domainValue(t, map[string]tftypes.Value{
+			"name":                tftypes.NewValue(tftypes.String, "example.com"),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, protection),
+			"force_destroy":       tftypes.NewValue(tftypes.Bool, forceDestroy),
+		})
+	}
+	protectMX := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "@/MX"),
+	})
+
+	type testCase struct {
+		name      string
+		protected bool
+		prior     tftypes.Value
+		private   []byte
+		errors    []string
+	}
+
+	tests := []testCase{
+		{"deletion protection", false, domain(true, false), nil, []string{"Domain Deletion Protected"}},
+		// The MX record is stored with an empty host.
+		{"protected apex record", true, domain(false, true), nil, []string{"Domain Contains Protected Records"}},
+		{"unmanaged record", false, domain(false, false), defaultRecordsPrivate(t, 1, 2, 3), []string{"Domain Contains Unmanaged Records"}},
+		{"force destroy", false, domain(false, true), defaultRecordsPrivate(t, 1, 2, 3), nil},
+		{"only default records", false, domain(false, false), defaultRecordsPrivate(t, 1, 2, 3, 4), nil},
+		// Imported domains have no record of their default records.
+		{"no default records known", false, domain(false, false), nil, nil},
+	}
+
+	for _, tt := range tests {
+		api, deletes := fakeZoneAPI(t, testZoneEntries)
+		config := map[string]tftypes.Value{"base_url": tftypes.NewValue(tftypes.String, api.URL)}
+		if tt.protected {
+			config["protected_records"] = protectMX
+		}
+
+		diags := destroyResource(t, testProviderServer(t, config), "fornex_domain", tt.prior, tt.private)
+
+		if got := errorSummaries(diags); !slices.Equal(got, tt.errors) {
+			t.Errorf("%s: expected errors %q, got: %q", tt.name, tt.errors, got)
+		}
+		deleted := slices.Equal(*deletes, []string{"/dns/domain/example.com/"})
+		if deleted != (tt.errors == nil) {
+			t.Errorf("%s: expected domain deleted to be %v, got requests: %q", tt.name, tt.errors == nil, *deletes)
+		}
+	}
+}
+
+func TestRecordDeleteProtected(t *testing.T) {
+	api, deletes := fakeZoneAPI(t, `[]`)
+	server := testProviderServer(t, map[string]tftypes.Value{
+		"base_url": tftypes.NewValue(tftypes.String, api.URL),
+		"protected_records": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "@/MX"),
+		}),
+	})
+
+	record := func(host, typ string) tftypes.Value {
+		return recordValue(t, map[string]tftypes.Value{
+			"id":          tftypes.NewValue(tftypes.String, "example.com/3"),
+			"record_id":   tftypes.NewValue(tftypes.Number, 3),
+			"domain_name": tftypes.NewValue(tftypes.String, "example.com"),
+			"host":        tftypes.NewValue(tftypes.String, host),
+			"type":        tftypes.NewValue(tftypes.String, typ),
+			"value":       tftypes.NewValue(tftypes.String, "mail.example.com"),
+		})
+	}
+
+	for _, host := range []string{"@", "", "example.com"} {
+		diags := destroyResource(t, server, "fornex_record", record(host, "MX"), nil)
+		if got := errorSummaries(diags); !slices.Equal(got, []string{"Record Deletion Protected"}) {
+			t.Errorf("Expected apex MX with host %q to be protected, got: %q", host, got)
+		}
+	}
+	if len(*deletes) != 0 {
+		t.Fatalf("Expected no deletes, got: %q", *deletes)
+	}
+
+	if diags := destroyResource(t, server, "fornex_record", record("www", "MX"), nil); len(errorSummaries(diags)) != 0 {
+		t.Errorf("Expected no error, got: %v", diags)
+	}
+	if !slices.Equal(*deletes, []string{"/dns/domain/example.com/entry_set/3/"}) {
+		t.Errorf("Expected the unprotected record to be deleted, got: %q", *deletes)
+	}
+}
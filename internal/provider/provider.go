@@ -2,26 +2,80 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/mglants/terraform-provider-fornex/internal/client"
+	"github.com/mglants/terraform-provider-fornex/internal/credentials"
 )
 
 // Ensure FornexProvider implements the provider.Provider interface.
 var _ provider.Provider = &FornexProvider{}
+var _ provider.ProviderWithFunctions = &FornexProvider{}
+var _ provider.ProviderWithListResources = &FornexProvider{}
+
+// Default operation timeouts, used when a resource has no timeouts block.
+const (
+	defaultCreateTimeout = 10 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 10 * time.Minute
+	defaultDeleteTimeout = 10 * time.Minute
+)
 
 type FornexProvider struct {
 	version string
 }
 
 type FornexProviderModel struct {
-	APIKey  types.String `tfsdk:"api_key"`
-	BaseURL types.String `tfsdk:"base_url"`
+	APIKey           types.String `tfsdk:"api_key"`
+	APIKeyFile       types.String `tfsdk:"api_key_file"`
+	APIKeyCommand    types.String `tfsdk:"api_key_command"`
+	Profile          types.String `tfsdk:"profile"`
+	SharedCredsFile  types.String `tfsdk:"shared_credentials_file"`
+	BaseURL          types.String `tfsdk:"base_url"`
+	RequestTimeout   types.String `tfsdk:"request_timeout"`
+	UserAgentSuffix  types.String `tfsdk:"user_agent_suffix"`
+	SkipCredsCheck   types.Bool   `tfsdk:"skip_credentials_validation"`
+	ReadOnly         types.Bool   `tfsdk:"read_only"`
+	AuditLogPath     types.String `tfsdk:"audit_log_path"`
+	ProtectedRecords types.List   `tfsdk:"protected_records"`
+	DefaultTTL       types.Int64  `tfsdk:"default_ttl"`
+	DefaultTTLs      types.Map    `tfsdk:"default_ttls"`
+
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ClientCertFile     types.String `tfsdk:"client_cert_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyFile      types.String `tfsdk:"client_key_file"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+}
+
+// ProviderData is handed to every resource and data source once the provider
+// has been configured.
+type ProviderData struct {
+	Client           *client.Client
+	ProtectedRecords []recordPattern
+	RecordDefaults   recordDefaults
 }
 
 func (p *FornexProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -38,10 +92,121 @@ func (p *FornexProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"api_key_file": schema.StringAttribute{
+				Description: "Path to a file containing the Fornex API key.",
+				Optional:    true,
+			},
+			"api_key_command": schema.StringAttribute{
+				Description: "Command run through the system shell whose standard output is the Fornex API key, " +
+					"e.g. a secrets manager CLI.",
+				Optional: true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "Profile to read from the shared credentials file. Defaults to `default`. " +
+					"Can also be set via `FORNEX_PROFILE` environment variable.",
+				Optional: true,
+			},
+			"shared_credentials_file": schema.StringAttribute{
+				Description: "Path to the shared credentials file. Defaults to `~/.config/fornex/credentials`. " +
+					"Can also be set via `FORNEX_SHARED_CREDENTIALS_FILE` environment variable.",
+				Optional: true,
+			},
 			"base_url": schema.StringAttribute{
 				Description: "Fornex API base URL. Defaults to `https://fornex.com/api`. Can also be set via `FORNEX_BASE_URL` environment variable.",
 				Optional:    true,
 			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Timeout for a single API request, e.g. `30s` or `2m`. Defaults to `1m`. " +
+					"Can also be set via `FORNEX_REQUEST_TIMEOUT` environment variable.",
+				Optional:   true,
+				Validators: []validator.String{durationValidator{}},
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of the HTTP(S) proxy to send API requests through. Defaults to the standard " +
+					"`HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional: true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM bundle of additional certificate authorities to trust, e.g. for a TLS intercepting proxy.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem")),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM bundle of additional certificate authorities to trust.",
+				Optional:    true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Disable TLS certificate verification. Only use this for testing.",
+				Optional:    true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM client certificate for mutual TLS. Requires a client key.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_cert_pem")),
+				},
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Description: "PEM client certificate for mutual TLS. Requires a client key.",
+				Optional:    true,
+			},
+			"client_key_file": schema.StringAttribute{
+				Description: "Path to the PEM private key of the client certificate.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_key_pem": schema.StringAttribute{
+				Description: "PEM private key of the client certificate.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Description: "Text appended to the User-Agent of API requests, e.g. to identify a CI pipeline. " +
+					"Can also be set via `FORNEX_USER_AGENT_SUFFIX` environment variable.",
+				Optional: true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Description: "Skip checking the API key and base URL with a test request when the provider is configured. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "Refuse every API request that would modify data, while reads and data sources keep working. " +
+					"Useful for plan-only pipelines. Can also be set via `FORNEX_READ_ONLY` environment variable.",
+				Optional: true,
+			},
+			"audit_log_path": schema.StringAttribute{
+				Description: "Path of a JSON Lines file to which every domain and record change is appended, with timestamp, " +
+					"actor, before and after payloads and outcome. Can also be set via `FORNEX_AUDIT_LOG_PATH` environment variable.",
+				Optional: true,
+			},
+			"protected_records": schema.ListAttribute{
+				Description: "Records that must never be deleted, as `host` or `host/TYPE` glob patterns (e.g. `@/MX`, `_dmarc/TXT`, `*/NS`). " +
+					"Deleting a matching `fornex_record`, or a `fornex_domain` that still contains a matching record, fails.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"default_ttl": schema.Int64Attribute{
+				Description: "TTL planned for `fornex_record` resources that do not set `ttl`. " +
+					"Can also be set via `FORNEX_DEFAULT_TTL` environment variable.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"default_ttls": schema.MapAttribute{
+				Description: "Default TTL per record type, e.g. `{ MX = 3600, TXT = 300 }`. Takes precedence over `default_ttl`.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.OneOf(recordTypes...)),
+					mapvalidator.ValueInt64sAre(int64validator.AtLeast(1)),
+				},
+			},
 		},
 	}
 }
@@ -55,22 +220,126 @@ func (p *FornexProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
-	apiKey := os.Getenv("FORNEX_API_KEY")
 	baseURL := os.Getenv("FORNEX_BASE_URL")
-
-	if !data.APIKey.IsNull() {
-		apiKey = data.APIKey.ValueString()
-	}
+	requestTimeout := os.Getenv("FORNEX_REQUEST_TIMEOUT")
+	userAgentSuffix := os.Getenv("FORNEX_USER_AGENT_SUFFIX")
+	readOnly, _ := strconv.ParseBool(os.Getenv("FORNEX_READ_ONLY"))
+	auditLogPath := os.Getenv("FORNEX_AUDIT_LOG_PATH")
+	defaultTTL := os.Getenv("FORNEX_DEFAULT_TTL")
 
 	if !data.BaseURL.IsNull() {
 		baseURL = data.BaseURL.ValueString()
 	}
 
-	if apiKey == "" {
+	if !data.RequestTimeout.IsNull() {
+		requestTimeout = data.RequestTimeout.ValueString()
+	}
+
+	if !data.UserAgentSuffix.IsNull() {
+		userAgentSuffix = data.UserAgentSuffix.ValueString()
+	}
+
+	if !data.ReadOnly.IsNull() {
+		readOnly = data.ReadOnly.ValueBool()
+	}
+
+	if !data.AuditLogPath.IsNull() {
+		auditLogPath = data.AuditLogPath.ValueString()
+	}
+
+	// Credential sources are tried in the order documented in the
+	// credentials package: api_key, api_key_file, api_key_command, a
+	// configured profile or shared_credentials_file, FORNEX_API_KEY and
+	// finally the shared credentials file selected by the environment.
+	apiKey, source, err := credentials.Resolve(ctx, credentials.Config{
+		APIKey:                data.APIKey.ValueString(),
+		APIKeyFile:            data.APIKeyFile.ValueString(),
+		APIKeyCommand:         data.APIKeyCommand.ValueString(),
+		Profile:               data.Profile.ValueString(),
+		SharedCredentialsFile: data.SharedCredsFile.ValueString(),
+	})
+	if errors.Is(err, credentials.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Missing API Key",
 			"The provider cannot create the Fornex API client as there is no API key. "+
-				"Set the api_key provider block field or the FORNEX_API_KEY environment variable.",
+				"Set the api_key, api_key_file or api_key_command provider block field, the FORNEX_API_KEY "+
+				"environment variable, or add the key to the shared credentials file.",
+		)
+	} else if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Load API Key",
+			fmt.Sprintf("The provider cannot read the Fornex API key: %s", err),
+		)
+	} else {
+		tflog.Debug(ctx, "Loaded Fornex API key", map[string]any{"source": source})
+	}
+
+	timeout := client.DefaultRequestTimeout
+	if requestTimeout != "" {
+		d, err := time.ParseDuration(requestTimeout)
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				fmt.Sprintf("Expected a positive duration such as \"30s\" or \"2m\", got: %q", requestTimeout),
+			)
+		}
+		timeout = d
+	}
+
+	var protected []recordPattern
+	if !data.ProtectedRecords.IsNull() {
+		var patterns []string
+		resp.Diagnostics.Append(data.ProtectedRecords.ElementsAs(ctx, &patterns, false)...)
+
+		for _, p := range patterns {
+			pattern, err := parseRecordPattern(p)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("protected_records"),
+					"Invalid Protected Record Pattern",
+					err.Error(),
+				)
+				continue
+			}
+			protected = append(protected, pattern)
+		}
+	}
+
+	var defaults recordDefaults
+	if !data.DefaultTTL.IsNull() {
+		ttl := data.DefaultTTL.ValueInt64()
+		defaults.TTL = &ttl
+	} else if defaultTTL != "" {
+		ttl, err := strconv.ParseInt(defaultTTL, 10, 64)
+		if err != nil || ttl < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_ttl"),
+				"Invalid Default TTL",
+				fmt.Sprintf("Expected a positive number of seconds in FORNEX_DEFAULT_TTL, got: %q", defaultTTL),
+			)
+		}
+		defaults.TTL = &ttl
+	}
+
+	if !data.DefaultTTLs.IsNull() {
+		resp.Diagnostics.Append(data.DefaultTTLs.ElementsAs(ctx, &defaults.TTLByType, false)...)
+	}
+
+	transportConfig := client.TransportConfig{
+		ProxyURL:           data.ProxyURL.ValueString(),
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+		CACertPEM:          readPEM(data.CACertPEM, data.CACertFile, path.Root("ca_cert_file"), &resp.Diagnostics),
+		ClientCertPEM:      readPEM(data.ClientCertPEM, data.ClientCertFile, path.Root("client_cert_file"), &resp.Diagnostics),
+		ClientKeyPEM:       readPEM(data.ClientKeyPEM, data.ClientKeyFile, path.Root("client_key_file"), &resp.Diagnostics),
+	}
+
+	if transportConfig.InsecureSkipVerify {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Verification Disabled",
+			"The provider does not verify the TLS certificate of the Fornex API. "+
+				"The API key and all DNS data can be intercepted. Prefer ca_cert_file for TLS intercepting proxies.",
 		)
 	}
 
@@ -78,9 +347,88 @@ func (p *FornexProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		return
 	}
 
+	transport, err := client.NewTransport(transportConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid HTTP Transport Settings", fmt.Sprintf("Unable to configure the HTTP transport: %s", err))
+		return
+	}
+
 	c := client.NewClient(apiKey, baseURL)
-	resp.DataSourceData = c
-	resp.ResourceData = c
+	c.HTTPClient.Timeout = timeout
+	c.HTTPClient.Transport = transport
+	c.UserAgent = client.UserAgent(p.version, req.TerraformVersion, userAgentSuffix)
+	c.ReadOnly = readOnly
+
+	if auditLogPath != "" {
+		auditLog, err := client.NewAuditLog(auditLogPath, "")
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("audit_log_path"), "Unable to Open Audit Log", err.Error())
+			return
+		}
+		c.AuditLog = auditLog
+	}
+
+	if !data.SkipCredsCheck.ValueBool() {
+		if err := c.CheckCredentials(ctx); err != nil {
+			summary, detail := credentialsErrorDiagnostic(err, c.BaseURL)
+			resp.Diagnostics.AddError(summary, detail)
+			return
+		}
+	}
+
+	pd := &ProviderData{
+		Client:           c,
+		ProtectedRecords: protected,
+		RecordDefaults:   defaults,
+	}
+	resp.DataSourceData = pd
+	resp.ResourceData = pd
+	resp.ListResourceData = pd
+}
+
+// credentialsErrorDiagnostic turns a failed credentials check into an
+// actionable diagnostic.
+func credentialsErrorDiagnostic(err error, baseURL string) (string, string) {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return "Fornex API Unreachable",
+			fmt.Sprintf("Unable to reach the Fornex API at %s: %s\n\n"+
+				"Check base_url and the network connection, including proxy and TLS settings.", baseURL, err)
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized:
+		return "Invalid Fornex API Key",
+			"The Fornex API rejected the API key. Check that it is correct and has not expired or been revoked.\n\n" + err.Error()
+	case http.StatusForbidden:
+		return "Insufficient Fornex API Key Permissions",
+			"The Fornex API key is valid but is not allowed to access DNS. Grant it DNS permissions in the Fornex panel.\n\n" + err.Error()
+	case http.StatusNotFound:
+		return "Fornex API Not Found",
+			fmt.Sprintf("The DNS API was not found at %s. Check base_url.\n\n%s", baseURL, err)
+	default:
+		return "Fornex API Error",
+			fmt.Sprintf("Checking the Fornex API credentials failed: %s\n\n"+
+				"Set skip_credentials_validation = true to skip this check.", err)
+	}
+}
+
+// readPEM returns the PEM contents from an inline attribute or, if that is
+// unset, from the file named by the file attribute.
+func readPEM(inline, file types.String, filePath path.Path, diags *diag.Diagnostics) []byte {
+	if inline.ValueString() != "" {
+		return []byte(inline.ValueString())
+	}
+	if file.ValueString() == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(file.ValueString())
+	if err != nil {
+		diags.AddAttributeError(filePath, "Unable to Read File", err.Error())
+		return nil
+	}
+	return data
 }
 
 func (p *FornexProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -97,6 +445,23 @@ func (p *FornexProvider) DataSources(ctx context.Context) []func() datasource.Da
 	}
 }
 
+func (p *FornexProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewDomainListResource,
+		NewRecordListResource,
+	}
+}
+
+func (p *FornexProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewFQDNFunction,
+		NewRelativeHostFunction,
+		NewSplitTXTFunction,
+		NewReversePTRNameFunction,
+		NewSRVValueFunction,
+	}
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &FornexProvider{
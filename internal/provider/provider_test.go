@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// objectValue returns an object of type typ with the given attributes set
+// and all others null.
+func objectValue(typ tftypes.Object, attrs map[string]tftypes.Value) tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, v := range attrs {
+		values[name] = v
+	}
+	return tftypes.NewValue(typ, values)
+}
+
+func resourceType(r resource.Resource) tftypes.Object {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	return schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+}
+
+// domainValue returns a fornex_domain object with the given attributes set
+// and all others null.
+func domainValue(t *testing.T, attrs map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+	return objectValue(resourceType(&DomainResource{}), attrs)
+}
+
+// recordValue returns a fornex_record object with the given attributes set
+// and all others null.
+func recordValue(t *testing.T, attrs map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+	return objectValue(resourceType(&RecordResource{}), attrs)
+}
+
+// testProviderServer returns a provider server configured with the given
+// provider attributes. A base_url configures an API key and skips the
+// credentials check.
+func testProviderServer(t *testing.T, config map[string]tftypes.Value) tfprotov6.ProviderServer {
+	t.Helper()
+
+	ctx := context.Background()
+	p := New("test")()
+	schemaResp := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	attrs := map[string]tftypes.Value{}
+	if _, ok := config["base_url"]; ok {
+		attrs["api_key"] = tftypes.NewValue(tftypes.String, "test-key")
+		attrs["skip_credentials_validation"] = tftypes.NewValue(tftypes.Bool, true)
+	}
+	for name, v := range config {
+		attrs[name] = v
+	}
+
+	server := providerserver.NewProtocol6(p)()
+	if config == nil {
+		return server
+	}
+
+	dv, err := tfprotov6.NewDynamicValue(typ, objectValue(typ, attrs))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &dv})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("Expected no error configuring the provider, got: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	return server
+}
+
+// planResource plans a change of a resource of typeName through server and
+// returns the planned state and the diagnostics.
+func planResource(t *testing.T, server tfprotov6.ProviderServer, typeName string, prior, config, proposed tftypes.Value) (tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	typ := config.Type()
+	dynamic := func(v tftypes.Value) *tfprotov6.DynamicValue {
+		dv, err := tfprotov6.NewDynamicValue(typ, v)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+		return &dv
+	}
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       dynamic(prior),
+		Config:           dynamic(config),
+		ProposedNewState: dynamic(proposed),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	planned, err := resp.PlannedState.Unmarshal(typ)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	return planned, resp.Diagnostics
+}
+
+// planDomain plans a fornex_domain change with an unconfigured provider and
+// returns the planned state.
+func planDomain(t *testing.T, prior, config, proposed tftypes.Value) (tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	planned, diags := planResource(t, testProviderServer(t, nil), "fornex_domain", prior, config, proposed)
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("Expected no error, got: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	return planned, diags
+}
+
+func plannedString(t *testing.T, planned tftypes.Value, name string) *string {
+	t.Helper()
+
+	var attrs map[string]tftypes.Value
+	if err := planned.As(&attrs); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	var s *string
+	if err := attrs[name].As(&s); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	return s
+}
+
+// destroyResource destroys a resource of typeName with the given prior state
+// and private state through server and returns the diagnostics.
+func destroyResource(t *testing.T, server tfprotov6.ProviderServer, typeName string, prior tftypes.Value, private []byte) []*tfprotov6.Diagnostic {
+	t.Helper()
+
+	typ := prior.Type()
+	dynamic := func(v tftypes.Value) *tfprotov6.DynamicValue {
+		dv, err := tfprotov6.NewDynamicValue(typ, v)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+		return &dv
+	}
+
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       typeName,
+		PriorState:     dynamic(prior),
+		PlannedState:   dynamic(tftypes.NewValue(typ, nil)),
+		Config:         dynamic(tftypes.NewValue(typ, nil)),
+		PlannedPrivate: private,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	return resp.Diagnostics
+}
+
+// errorSummaries returns the summaries of the error diagnostics.
+func errorSummaries(diags []*tfprotov6.Diagnostic) []string {
+	var summaries []string
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			summaries = append(summaries, d.Summary)
+		}
+	}
+	return summaries
+}
+
+// createResource creates a resource of typeName with the given planned state
+// through server and returns the new state and the diagnostics. Unknown
+// values of the plan are null in the configuration.
+func createResource(t *testing.T, server tfprotov6.ProviderServer, typeName string, plan tftypes.Value) (tftypes.Value, []*tfprotov6.Diagnostic) {
+	t.Helper()
+
+	typ := plan.Type()
+	dynamic := func(v tftypes.Value) *tfprotov6.DynamicValue {
+		dv, err := tfprotov6.NewDynamicValue(typ, v)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+		return &dv
+	}
+
+	config, err := tftypes.Transform(plan, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	resp, err := server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     typeName,
+		PriorState:   dynamic(tftypes.NewValue(typ, nil)),
+		PlannedState: dynamic(plan),
+		Config:       dynamic(config),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+
+	state := tftypes.NewValue(typ, nil)
+	if resp.NewState != nil {
+		if state, err = resp.NewState.Unmarshal(typ); err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+	}
+	return state, resp.Diagnostics
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+// recordConflicts are the problems found between a planned record and the
+// records that already exist in its domain.
+type recordConflicts struct {
+	// Duplicates have the same host, type and value as the planned record.
+	Duplicates []client.Entry
+	// CNAME lists records that cannot coexist with the planned record because
+	// one of them is a CNAME on the same host.
+	CNAME []client.Entry
+	// Wildcards lists wildcard records that stop answering for the planned
+	// host once it has a record of its own.
+	Wildcards []client.Entry
+}
+
+// findRecordConflicts compares planned with the existing entries of domain,
+// ignoring the entry with ID self (the record being updated).
+func findRecordConflicts(planned client.Entry, existing []client.Entry, domain string, self int) recordConflicts {
+	var conflicts recordConflicts
+
+	host := client.CanonicalHost(planned.Host, domain)
+	hostInUse := false
+	var wildcards []client.Entry
+
+	for _, e := range existing {
+		if self != 0 && e.ID == self {
+			continue
+		}
+
+		other := client.CanonicalHost(e.Host, domain)
+		if other != host {
+			if wildcardMatches(other, host) {
+				wildcards = append(wildcards, e)
+			}
+			continue
+		}
+		hostInUse = true
+
+		switch {
+		case strings.EqualFold(e.Type, planned.Type) && sameValue(e.Type, e.Value, planned.Value):
+			conflicts.Duplicates = append(conflicts.Duplicates, e)
+		case strings.EqualFold(planned.Type, "CNAME") || strings.EqualFold(e.Type, "CNAME"):
+			conflicts.CNAME = append(conflicts.CNAME, e)
+		}
+	}
+
+	// A host that already has records shadows the wildcard today, and
+	// wildcard records of the planned type are overridden on purpose.
+	if !hostInUse {
+		for _, e := range wildcards {
+			if !strings.EqualFold(e.Type, planned.Type) {
+				conflicts.Wildcards = append(conflicts.Wildcards, e)
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// wildcardMatches reports whether the wildcard host pattern ("*" or
+// "*.sub") covers host. Both are canonical hosts.
+func wildcardMatches(pattern, host string) bool {
+	if pattern == "*" {
+		return host != "@" && !strings.HasPrefix(host, "*")
+	}
+	parent, ok := strings.CutPrefix(pattern, "*.")
+	if !ok {
+		return false
+	}
+	return strings.HasSuffix(host, "."+parent) && !strings.HasPrefix(host, "*")
+}
+
+// sameValue compares record values, ignoring case and the trailing dot of
+// names for record types other than TXT.
+func sameValue(recordType, a, b string) bool {
+	if strings.EqualFold(recordType, "TXT") {
+		return strings.Trim(a, `"`) == strings.Trim(b, `"`)
+	}
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// checkConflicts looks up the existing records of the planned host before
+// anything is changed and warns about conflicts the API would reject. They are
+// not errors: the conflicting record may be destroyed in the same apply, e.g.
+// when a for_each key is renamed or an A record is replaced by a CNAME.
+func (r *RecordResource) checkConflicts(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil {
+		return
+	}
+
+	var plan, state RecordResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.DomainName.IsUnknown() || plan.Host.IsUnknown() || plan.Type.IsUnknown() || plan.Value.IsUnknown() {
+		return
+	}
+
+	self := 0
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Only host, type and value changes can introduce a conflict.
+		if plan.Host.Equal(state.Host) && plan.Type.Equal(state.Type) && plan.Value.Equal(state.Value) {
+			return
+		}
+		self = int(state.RecordID.ValueInt64())
+	}
+
+	domain := plan.DomainName.ValueString()
+	existing, err := r.client.ListEntries(ctx, domain)
+	if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			// The domain is created in the same apply.
+			return
+		}
+		resp.Diagnostics.AddWarning(
+			"Unable to Check Record Conflicts",
+			fmt.Sprintf("Unable to list the records of %s to check for conflicts, got error: %s", domain, err),
+		)
+		return
+	}
+
+	planned := client.Entry{
+		Host:  plan.Host.ValueString(),
+		Type:  plan.Type.ValueString(),
+		Value: plan.Value.ValueString(),
+	}
+	resp.Diagnostics.Append(conflictDiagnostics(planned, domain, findRecordConflicts(planned, existing, domain, self))...)
+}
+
+func conflictDiagnostics(planned client.Entry, domain string, conflicts recordConflicts) diag.Diagnostics {
+	var diags diag.Diagnostics
+	name := fmt.Sprintf("%s %s in domain %s", planned.Host, planned.Type, domain)
+
+	if len(conflicts.Duplicates) > 0 {
+		diags.AddAttributeWarning(
+			path.Root("value"),
+			"Duplicate Record",
+			fmt.Sprintf("Record %s with value %q already exists:\n%s\n\n"+
+				"Unless it is destroyed in the same apply, the API rejects the new record. "+
+				"Import the existing record instead of creating a new one.", name, planned.Value, describeEntries(conflicts.Duplicates)),
+		)
+	}
+
+	if len(conflicts.CNAME) > 0 {
+		diags.AddAttributeWarning(
+			path.Root("type"),
+			"CNAME Record Conflict",
+			fmt.Sprintf("A CNAME record cannot coexist with other records on the same host. Record %s conflicts with:\n%s\n\n"+
+				"Unless these records are destroyed in the same apply, the API rejects the new record.",
+				name, describeEntries(conflicts.CNAME)),
+		)
+	}
+
+	if len(conflicts.Wildcards) > 0 {
+		diags.AddAttributeWarning(
+			path.Root("host"),
+			"Record Shadows Wildcard",
+			fmt.Sprintf("Once record %s exists, these wildcard records no longer answer for %s:\n%s",
+				name, planned.Host, describeEntries(conflicts.Wildcards)),
+		)
+	}
+
+	return diags
+}
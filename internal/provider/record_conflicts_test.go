@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+func TestFindRecordConflicts(t *testing.T) {
+	existing := []client.Entry{
+		{ID: 1, Host: "www", Type: "A", Value: "192.0.2.1"},
+		{ID: 2, Host: "blog.example.com.", Type: "CNAME", Value: "ghs.example.net."},
+		{ID: 3, Host: "*", Type: "A", Value: "192.0.2.9"},
+		{ID: 4, Host: "*", Type: "MX", Value: "mail.example.com"},
+		{ID: 5, Host: "@", Type: "TXT", Value: "v=spf1 -all"},
+	}
+
+	type testCase struct {
+		name                          string
+		planned                       client.Entry
+		self                          int
+		duplicates, cnames, wildcards int
+	}
+
+	tests := []testCase{
+		{"duplicate", client.Entry{Host: "WWW", Type: "A", Value: "192.0.2.1"}, 0, 1, 0, 0},
+		{"duplicate name with trailing dot", client.Entry{Host: "blog", Type: "CNAME", Value: "ghs.example.net"}, 0, 1, 0, 0},
+		{"updating itself", client.Entry{Host: "www", Type: "A", Value: "192.0.2.1"}, 1, 0, 0, 1},
+		{"cname on used host", client.Entry{Host: "www", Type: "CNAME", Value: "example.net."}, 0, 0, 1, 0},
+		{"record on cname host", client.Entry{Host: "blog", Type: "TXT", Value: "hello"}, 0, 0, 1, 0},
+		{"second A record", client.Entry{Host: "www", Type: "A", Value: "192.0.2.2"}, 0, 0, 0, 0},
+		{"shadows wildcard", client.Entry{Host: "api", Type: "A", Value: "192.0.2.3"}, 0, 0, 0, 1},
+		{"shadows wildcard for all types", client.Entry{Host: "api", Type: "TXT", Value: "x"}, 0, 0, 0, 2},
+		{"apex is not covered by wildcard", client.Entry{Host: "example.com", Type: "A", Value: "192.0.2.3"}, 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		got := findRecordConflicts(tt.planned, existing, "example.com", tt.self)
+		if len(got.Duplicates) != tt.duplicates || len(got.CNAME) != tt.cnames || len(got.Wildcards) != tt.wildcards {
+			t.Errorf("%s: expected %d duplicates, %d CNAME conflicts and %d wildcards, got: %d, %d, %d",
+				tt.name, tt.duplicates, tt.cnames, tt.wildcards, len(got.Duplicates), len(got.CNAME), len(got.Wildcards))
+		}
+	}
+}
+
+func TestWildcardMatches(t *testing.T) {
+	tests := []struct {
+		pattern, host string
+		want          bool
+	}{
+		{"*", "www", true},
+		{"*", "a.b", true},
+		{"*", "@", false},
+		{"*.dev", "api.dev", true},
+		{"*.dev", "dev", false},
+		{"*.dev", "api.prod", false},
+		{"www", "www", false},
+	}
+
+	for _, tt := range tests {
+		if got := wildcardMatches(tt.pattern, tt.host); got != tt.want {
+			t.Errorf("wildcardMatches(%q, %q): expected %t, got %t", tt.pattern, tt.host, tt.want, got)
+		}
+	}
+}
+
+func TestRecordModifyPlanConflicts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[
+			{"id": 1, "host": "www", "type": "A", "value": "192.0.2.1", "ttl": 300},
+			{"id": 2, "host": "*", "type": "TXT", "value": "hello"}
+		]`))
+	}))
+	defer server.Close()
+
+	provider := testProviderServer(t, map[string]tftypes.Value{
+		"base_url": tftypes.NewValue(tftypes.String, server.URL),
+	})
+
+	plan := func(host, typ, value string) []*tfprotov6.Diagnostic {
+		config := recordValue(t, map[string]tftypes.Value{
+			"domain_name": tftypes.NewValue(tftypes.String, "example.com"),
+			"host":        tftypes.NewValue(tftypes.String, host),
+			"type":        tftypes.NewValue(tftypes.String, typ),
+			"value":       tftypes.NewValue(tftypes.String, value),
+		})
+		_, diags := planResource(t, provider, "fornex_record", tftypes.NewValue(config.Type(), nil), config, config)
+		return diags
+	}
+
+	type testCase struct {
+		name     string
+		host     string
+		typ      string
+		value    string
+		warnings []string
+	}
+
+	tests := []testCase{
+		// The existing record may be destroyed in the same apply, e.g. when
+		// a for_each key is renamed, so conflicts never fail the plan.
+		{"duplicate", "www", "A", "192.0.2.1", []string{"Duplicate Record"}},
+		{"cname on used host", "www", "CNAME", "example.net.", []string{"CNAME Record Conflict"}},
+		{"shadows wildcard", "api", "A", "192.0.2.2", []string{"Record Shadows Wildcard"}},
+		{"no conflict", "www", "A", "192.0.2.2", nil},
+	}
+
+	for _, tt := range tests {
+		var warnings []string
+		for _, d := range plan(tt.host, tt.typ, tt.value) {
+			if d.Severity == tfprotov6.DiagnosticSeverityError {
+				t.Errorf("%s: expected no error, got: %s: %s", tt.name, d.Summary, d.Detail)
+				continue
+			}
+			warnings = append(warnings, d.Summary)
+		}
+		if !slices.Equal(warnings, tt.warnings) {
+			t.Errorf("%s: expected warnings %q, got: %q", tt.name, tt.warnings, warnings)
+		}
+	}
+}
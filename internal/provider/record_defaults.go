@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+// recordTypes are the record types supported by the Fornex API.
+var recordTypes = client.RecordTypes
+
+// recordDefaults holds the provider-level defaults for fornex_record.
+type recordDefaults struct {
+	// TTL applies to records of any type without a type specific default.
+	TTL *int64
+	// TTLByType maps record types to their default TTL.
+	TTLByType map[string]int64
+}
+
+// ttl returns the default TTL for records of the given type.
+func (d recordDefaults) ttl(recordType string) (int64, bool) {
+	if ttl, ok := d.TTLByType[recordType]; ok {
+		return ttl, true
+	}
+	if d.TTL != nil {
+		return *d.TTL, true
+	}
+	return 0, false
+}
+
+// applyDefaults plans the provider default TTL for records that do not set
+// one, so the plan shows the value that is sent to the API.
+func (r *RecordResource) applyDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var configTTL types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &configTTL)...)
+
+	var recordType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+
+	if resp.Diagnostics.HasError() || !configTTL.IsNull() || recordType.IsUnknown() {
+		return
+	}
+
+	if ttl, ok := r.defaults.ttl(recordType.ValueString()); ok {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), types.Int64Value(ttl))...)
+	}
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRecordDefaultsTTL(t *testing.T) {
+	ttl := int64(3600)
+	defaults := recordDefaults{TTL: &ttl, TTLByType: map[string]int64{"TXT": 300}}
+
+	if got, ok := defaults.ttl("TXT"); !ok || got != 300 {
+		t.Errorf("Expected the TXT default to take precedence, got: %d, %t", got, ok)
+	}
+	if got, ok := defaults.ttl("A"); !ok || got != 3600 {
+		t.Errorf("Expected the default TTL for A records, got: %d, %t", got, ok)
+	}
+	if _, ok := (recordDefaults{}).ttl("A"); ok {
+		t.Errorf("Expected no default without provider defaults")
+	}
+}
+
+func TestRecordModifyPlanDefaultTTL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	number := func(v any) tftypes.Value {
+		if v == nil {
+			return tftypes.NewValue(tftypes.Number, nil)
+		}
+		if v == tftypes.UnknownValue {
+			return tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)
+		}
+		return tftypes.NewValue(tftypes.Number, big.NewFloat(float64(v.(int))))
+	}
+
+	defaultTTLs := tftypes.NewValue(tftypes.Map{ElementType: tftypes.Number}, map[string]tftypes.Value{
+		"TXT": number(300),
+	})
+
+	type testCase struct {
+		name     string
+		provider map[string]tftypes.Value
+		typ      string
+		// prior is the TTL in state, nil for a new record.
+		prior     any
+		configTTL any
+		expected  any
+	}
+
+	tests := []testCase{
+		{"default_ttls over default_ttl", map[string]tftypes.Value{"default_ttl": number(3600), "default_ttls": defaultTTLs}, "TXT", nil, nil, 300},
+		{"default_ttl for other types", map[string]tftypes.Value{"default_ttl": number(3600), "default_ttls": tftypes.NewValue(tftypes.Map{ElementType: tftypes.Number}, map[string]tftypes.Value{"MX": number(300)})}, "A", nil, nil, 3600},
+		{"explicit ttl", map[string]tftypes.Value{"default_ttl": number(3600), "default_ttls": defaultTTLs}, "TXT", nil, 60, 60},
+		{"changed default updates the record", map[string]tftypes.Value{"default_ttl": number(3600), "default_ttls": defaultTTLs}, "TXT", 3600, nil, 300},
+		{"explicit ttl on update", map[string]tftypes.Value{"default_ttls": defaultTTLs}, "TXT", 300, 60, 60},
+		{"no default on create", map[string]tftypes.Value{}, "TXT", nil, nil, tftypes.UnknownValue},
+		{"no default keeps the API value", map[string]tftypes.Value{}, "TXT", 600, nil, 600},
+	}
+
+	for _, tt := range tests {
+		tt.provider["base_url"] = tftypes.NewValue(tftypes.String, server.URL)
+		provider := testProviderServer(t, tt.provider)
+
+		attrs := map[string]tftypes.Value{
+			"domain_name": tftypes.NewValue(tftypes.String, "example.com"),
+			"host":        tftypes.NewValue(tftypes.String, "www"),
+			"type":        tftypes.NewValue(tftypes.String, tt.typ),
+			"value":       tftypes.NewValue(tftypes.String, "192.0.2.1"),
+		}
+		attrs["ttl"] = number(tt.configTTL)
+		config := recordValue(t, attrs)
+
+		prior := tftypes.NewValue(config.Type(), nil)
+		proposed := config
+		if tt.prior != nil {
+			attrs["id"] = tftypes.NewValue(tftypes.String, "example.com/1")
+			attrs["record_id"] = number(1)
+			attrs["ttl"] = number(tt.prior)
+			prior = recordValue(t, attrs)
+
+			// Terraform proposes the prior value of computed attributes
+			// that are not configured.
+			if tt.configTTL != nil {
+				attrs["ttl"] = number(tt.configTTL)
+			}
+			proposed = recordValue(t, attrs)
+		}
+
+		planned, diags := planResource(t, provider, "fornex_record", prior, config, proposed)
+		if got := errorSummaries(diags); len(got) != 0 {
+			t.Fatalf("%s: expected no error, got: %v", tt.name, diags)
+		}
+
+		var values map[string]tftypes.Value
+		if err := planned.As(&values); err != nil {
+			t.Fatalf("Expected no error, got: %s", err)
+		}
+		if !values["ttl"].Equal(number(tt.expected)) {
+			t.Errorf("%s: expected ttl %v, got: %s", tt.name, tt.expected, values["ttl"])
+		}
+	}
+}
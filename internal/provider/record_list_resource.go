@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+var _ list.ListResource = &RecordListResource{}
+var _ list.ListResourceWithConfigure = &RecordListResource{}
+
+type RecordListResource struct {
+	client *client.Client
+}
+
+type RecordListResourceModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	Host       types.String `tfsdk:"host"`
+	Type       types.String `tfsdk:"type"`
+}
+
+func NewRecordListResource() list.ListResource {
+	return &RecordListResource{}
+}
+
+func (r *RecordListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record"
+}
+
+func (r *RecordListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Description: "Lists the records of a Fornex domain, e.g. to generate import configuration with `terraform query`.",
+		Attributes: map[string]listschema.Attribute{
+			"domain_name": listschema.StringAttribute{
+				Description: "The domain whose records are listed.",
+				Required:    true,
+			},
+			"host": listschema.StringAttribute{
+				Description: "Only list records of this host, e.g. `www` or `@` for the apex.",
+				Optional:    true,
+			},
+			"type": listschema.StringAttribute{
+				Description: "Only list records of this type.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(recordTypes...),
+				},
+			},
+		},
+	}
+}
+
+func (r *RecordListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	pd, ok := req.ProviderData.(*ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = pd.Client
+}
+
+func (r *RecordListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config RecordListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	domainName := config.DomainName.ValueString()
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		for entry, err := range r.client.Entries(ctx, domainName) {
+			if err != nil {
+				result := req.NewListResult(ctx)
+				result.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list records, got error: %s", err))
+				push(result)
+				return
+			}
+
+			// fornex_record cannot manage the SOA record or other types the
+			// API lists but does not support.
+			if !slices.Contains(client.RecordTypes, strings.ToUpper(entry.Type)) {
+				continue
+			}
+			if !config.Host.IsNull() && client.CanonicalHost(entry.Host, domainName) != client.CanonicalHost(config.Host.ValueString(), domainName) {
+				continue
+			}
+			if !config.Type.IsNull() && !strings.EqualFold(entry.Type, config.Type.ValueString()) {
+				continue
+			}
+
+			recordID := types.Int64Value(int64(entry.ID))
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = fmt.Sprintf("%s %s %s", entry.Host, entry.Type, entry.Value)
+			result.Diagnostics.Append(result.Identity.Set(ctx, RecordIdentityModel{
+				DomainName: config.DomainName,
+				RecordID:   recordID,
+			})...)
+
+			if req.IncludeResource {
+				data := RecordResourceModel{
+					ID:         types.StringValue(formatRecordID(domainName, int64(entry.ID))),
+					RecordID:   recordID,
+					DomainName: config.DomainName,
+					Host:       types.StringValue(entry.Host),
+					Type:       types.StringValue(entry.Type),
+					Value:      types.StringValue(entry.Value),
+					TTL:        types.Int64PointerValue(intPointerToInt64(entry.TTL)),
+					Priority:   types.Int64PointerValue(intPointerToInt64(entry.Priority)),
+					Timeouts:   nullTimeouts(),
+				}
+				result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+
+			count++
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+		}
+	}
+}
+
+func intPointerToInt64(v *int) *int64 {
+	if v == nil {
+		return nil
+	}
+	i := int64(*v)
+	return &i
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/mglants/terraform-provider-fornex/internal/dnscheck"
+)
+
+const (
+	defaultPropagationTimeout  = 5 * time.Minute
+	defaultPropagationInterval = 10 * time.Second
+)
+
+type RecordPropagationModel struct {
+	Timeout       types.String `tfsdk:"timeout"`
+	PollInterval  types.String `tfsdk:"poll_interval"`
+	Nameservers   types.List   `tfsdk:"nameservers"`
+	FailOnTimeout types.Bool   `tfsdk:"fail_on_timeout"`
+}
+
+func recordPropagationBlock() schema.Block {
+	return schema.SingleNestedBlock{
+		Description: "Wait until the authoritative nameservers serve the record value after it is created or updated.",
+		Attributes: map[string]schema.Attribute{
+			"timeout": schema.StringAttribute{
+				Description: "How long to wait for the record to propagate, e.g. `5m`. Defaults to `5m`.",
+				Optional:    true,
+				Validators:  []validator.String{durationValidator{}},
+			},
+			"poll_interval": schema.StringAttribute{
+				Description: "Delay between two rounds of queries, e.g. `10s`. Defaults to `10s`.",
+				Optional:    true,
+				Validators:  []validator.String{durationValidator{}},
+			},
+			"nameservers": schema.ListAttribute{
+				Description: "Nameserver addresses (`host` or `host:port`) to query. Defaults to the authoritative nameservers of the domain.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"fail_on_timeout": schema.BoolAttribute{
+				Description: "Fail the apply when the record does not propagate within `timeout`. The record is saved " +
+					"either way, but a failed create taints it, so the next apply replaces it. Defaults to `false`, " +
+					"which only warns.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// waitForPropagation blocks until every nameserver serves the record from
+// data, as configured by its wait_for_propagation block. The record is
+// already saved, so running out of time is only a warning unless
+// fail_on_timeout is set.
+func (r *RecordResource) waitForPropagation(ctx context.Context, data *RecordResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cfg := data.WaitForPropagation
+	if cfg == nil {
+		return diags
+	}
+
+	timeout := defaultPropagationTimeout
+	if !cfg.Timeout.IsNull() {
+		timeout, _ = time.ParseDuration(cfg.Timeout.ValueString())
+	}
+	interval := defaultPropagationInterval
+	if !cfg.PollInterval.IsNull() {
+		interval, _ = time.ParseDuration(cfg.PollInterval.ValueString())
+	}
+
+	var nameservers []string
+	if !cfg.Nameservers.IsNull() {
+		diags.Append(cfg.Nameservers.ElementsAs(ctx, &nameservers, false)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	if len(nameservers) == 0 {
+		domain, err := r.client.GetDomain(ctx, data.DomainName.ValueString())
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to look up domain nameservers, got error: %s", err))
+			return diags
+		}
+		nameservers = domain.Nameservers()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	record := dnscheck.Record{
+		Name:  dnscheck.FQDN(data.Host.ValueString(), data.DomainName.ValueString()),
+		Type:  data.Type.ValueString(),
+		Value: data.Value.ValueString(),
+	}
+	if err := dnscheck.NewChecker(nameservers, interval).Wait(ctx, record); err != nil {
+		summary := "Record Not Propagated"
+		detail := fmt.Sprintf("The record was saved but did not propagate within %s: %s", timeout, err)
+		if cfg.FailOnTimeout.ValueBool() {
+			diags.AddError(summary, detail)
+		} else {
+			diags.AddWarning(summary, detail)
+		}
+	}
+
+	return diags
+}
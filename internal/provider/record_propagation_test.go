@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestWaitForPropagationTimeout(t *testing.T) {
+	// A nameserver that never answers.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	defer conn.Close()
+
+	wait := func(failOnTimeout types.Bool) diag.Diagnostics {
+		data := &RecordResourceModel{
+			DomainName: types.StringValue("example.com"),
+			Host:       types.StringValue("www"),
+			Type:       types.StringValue("A"),
+			Value:      types.StringValue("192.0.2.1"),
+			WaitForPropagation: &RecordPropagationModel{
+				Timeout:       types.StringValue("200ms"),
+				PollInterval:  types.StringValue("50ms"),
+				Nameservers:   types.ListValueMust(types.StringType, []attr.Value{types.StringValue(conn.LocalAddr().String())}),
+				FailOnTimeout: failOnTimeout,
+			},
+		}
+		return (&RecordResource{}).waitForPropagation(context.Background(), data)
+	}
+
+	// The record is already saved, so a slow nameserver only warns.
+	if diags := wait(types.BoolNull()); diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("Expected a single warning, got: %v", diags)
+	}
+	if diags := wait(types.BoolValue(true)); !diags.HasError() {
+		t.Errorf("Expected an error with fail_on_timeout, got: %v", diags)
+	}
+}
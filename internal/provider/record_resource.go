@@ -3,12 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -20,19 +20,35 @@ import (
 
 var _ resource.Resource = &RecordResource{}
 var _ resource.ResourceWithImportState = &RecordResource{}
+var _ resource.ResourceWithModifyPlan = &RecordResource{}
+var _ resource.ResourceWithUpgradeState = &RecordResource{}
+var _ resource.ResourceWithIdentity = &RecordResource{}
 
 type RecordResource struct {
-	client *client.Client
+	client           *client.Client
+	protectedRecords []recordPattern
+	defaults         recordDefaults
 }
 
 type RecordResourceModel struct {
-	ID         types.Int64  `tfsdk:"id"`
+	ID         types.String `tfsdk:"id"`
+	RecordID   types.Int64  `tfsdk:"record_id"`
 	DomainName types.String `tfsdk:"domain_name"`
 	Host       types.String `tfsdk:"host"`
 	Type       types.String `tfsdk:"type"`
 	TTL        types.Int64  `tfsdk:"ttl"`
 	Value      types.String `tfsdk:"value"`
 	Priority   types.Int64  `tfsdk:"priority"`
+
+	WaitForPropagation *RecordPropagationModel `tfsdk:"wait_for_propagation"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// RecordIdentityModel is the resource identity of a record.
+type RecordIdentityModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	RecordID   types.Int64  `tfsdk:"record_id"`
 }
 
 func NewRecordResource() resource.Resource {
@@ -46,9 +62,17 @@ func (r *RecordResource) Metadata(ctx context.Context, req resource.MetadataRequ
 func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Provides a Fornex DNS record resource. This can be used to create, modify, and delete DNS records.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
-			"id": schema.Int64Attribute{
-				Description: "The ID of the record.",
+			"id": schema.StringAttribute{
+				Description: "The ID of the record in the form `domain_name/record_id`.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"record_id": schema.Int64Attribute{
+				Description: "The numeric ID of the record in the Fornex API.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
@@ -69,12 +93,17 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Description: "The type of the record (A, AAAA, CAA, CNAME, MX, NS, SRV, TXT).",
 				Required:    true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("A", "AAAA", "CAA", "CNAME", "MX", "NS", "SRV", "TXT"),
+					stringvalidator.OneOf(recordTypes...),
 				},
 			},
 			"ttl": schema.Int64Attribute{
-				Description: "Time to live for the record.",
-				Optional:    true,
+				Description: "Time to live for the record. Defaults to the provider `default_ttls` entry for the record type, " +
+					"then the provider `default_ttl`, then the API default.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"value": schema.StringAttribute{
 				Description: "The value of the record.",
@@ -85,6 +114,30 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:    true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"wait_for_propagation": recordPropagationBlock(),
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *RecordResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name the record belongs to.",
+				RequiredForImport: true,
+			},
+			"record_id": identityschema.Int64Attribute{
+				Description:       "The numeric ID of the record.",
+				RequiredForImport: true,
+			},
+		},
 	}
 }
 
@@ -93,19 +146,38 @@ func (r *RecordResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.Client)
+	pd, ok := req.ProviderData.(*ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *provider.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = pd.Client
+	r.protectedRecords = pd.ProtectedRecords
+	r.defaults = pd.RecordDefaults
+}
+
+func (r *RecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	r.applyDefaults(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.checkConflicts(ctx, req, resp)
 }
 
 func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "fornex_record.Create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data RecordResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -114,13 +186,22 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	entry := client.Entry{
 		Host:  data.Host.ValueString(),
 		Type:  data.Type.ValueString(),
 		Value: data.Value.ValueString(),
 	}
 
-	if !data.TTL.IsNull() {
+	if !data.TTL.IsNull() && !data.TTL.IsUnknown() {
 		ttl := int(data.TTL.ValueInt64())
 		entry.TTL = &ttl
 	}
@@ -130,13 +211,14 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 		entry.Priority = &priority
 	}
 
-	newEntry, err := r.client.CreateEntry(data.DomainName.ValueString(), entry)
+	newEntry, err := r.client.CreateEntry(ctx, data.DomainName.ValueString(), entry)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create record, got error: %s", err))
 		return
 	}
 
-	data.ID = types.Int64Value(int64(newEntry.ID))
+	data.ID = types.StringValue(formatRecordID(data.DomainName.ValueString(), int64(newEntry.ID)))
+	data.RecordID = types.Int64Value(int64(newEntry.ID))
 	if newEntry.TTL != nil {
 		data.TTL = types.Int64Value(int64(*newEntry.TTL))
 	} else {
@@ -150,9 +232,18 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RecordIdentityModel{DomainName: data.DomainName, RecordID: data.RecordID})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.waitForPropagation(ctx, &data)...)
 }
 
 func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "fornex_record.Read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data RecordResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -161,7 +252,16 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	entry, err := r.client.GetEntry(data.DomainName.ValueString(), int(data.ID.ValueInt64()))
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	entry, err := r.client.GetEntry(ctx, data.DomainName.ValueString(), int(data.RecordID.ValueInt64()))
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read record, got error: %s", err))
 		return
@@ -183,9 +283,13 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RecordIdentityModel{DomainName: data.DomainName, RecordID: data.RecordID})...)
 }
 
 func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "fornex_record.Update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data RecordResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -194,13 +298,22 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	entry := client.Entry{
 		Host:  data.Host.ValueString(),
 		Type:  data.Type.ValueString(),
 		Value: data.Value.ValueString(),
 	}
 
-	if !data.TTL.IsNull() {
+	if !data.TTL.IsNull() && !data.TTL.IsUnknown() {
 		ttl := int(data.TTL.ValueInt64())
 		entry.TTL = &ttl
 	}
@@ -210,7 +323,7 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 		entry.Priority = &priority
 	}
 
-	updatedEntry, err := r.client.UpdateEntry(data.DomainName.ValueString(), int(data.ID.ValueInt64()), entry)
+	updatedEntry, err := r.client.UpdateEntry(ctx, data.DomainName.ValueString(), int(data.RecordID.ValueInt64()), entry)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update record, got error: %s", err))
 		return
@@ -229,9 +342,18 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, RecordIdentityModel{DomainName: data.DomainName, RecordID: data.RecordID})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.waitForPropagation(ctx, &data)...)
 }
 
 func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "fornex_record.Delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
 	var data RecordResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -240,34 +362,57 @@ func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteEntry(data.DomainName.ValueString(), int(data.ID.ValueInt64()))
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete record, got error: %s", err))
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-}
 
-func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	idParts := strings.Split(req.ID, ":")
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
-	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+	if pattern, ok := protectedBy(r.protectedRecords, data.DomainName.ValueString(), data.Host.ValueString(), data.Type.ValueString()); ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: domain_name:record_id. Got: %q", req.ID),
+			"Record Deletion Protected",
+			fmt.Sprintf("Record %s %s in domain %s matches the provider protected_records pattern %q and cannot be deleted.",
+				data.Host.ValueString(), data.Type.ValueString(), data.DomainName.ValueString(), pattern.raw),
 		)
 		return
 	}
 
-	domainName := idParts[0]
-	recordID, err := strconv.ParseInt(idParts[1], 10, 64)
+	err := r.client.DeleteEntry(ctx, data.DomainName.ValueString(), int(data.RecordID.ValueInt64()))
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unexpected Import Identifier",
-			fmt.Sprintf("Expected import identifier with format: domain_name:record_id. Got: %q", req.ID),
-		)
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete record, got error: %s", err))
 		return
 	}
+}
+
+func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var domainName string
+	var recordID int64
+
+	if req.ID != "" {
+		var err error
+		domainName, recordID, err = parseRecordID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unexpected Import Identifier",
+				fmt.Sprintf("Expected import identifier with format: domain_name/record_id. Got: %q", req.ID),
+			)
+			return
+		}
+	} else {
+		// Imported by identity, Terraform 1.12 and later.
+		var identity RecordIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		domainName = identity.DomainName.ValueString()
+		recordID = identity.RecordID.ValueInt64()
+	}
 
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), formatRecordID(domainName, recordID))...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), recordID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("record_id"), recordID)...)
 }
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// formatRecordID returns the resource ID of a record, "domain_name/record_id".
+func formatRecordID(domainName string, recordID int64) string {
+	return domainName + "/" + strconv.FormatInt(recordID, 10)
+}
+
+// parseRecordID splits a record ID into domain name and numeric record ID.
+// The "domain_name:record_id" form used by earlier import identifiers is
+// accepted as well.
+func parseRecordID(id string) (string, int64, error) {
+	i := strings.LastIndexAny(id, "/:")
+	if i <= 0 || i == len(id)-1 {
+		return "", 0, fmt.Errorf("expected domain_name/record_id, got: %q", id)
+	}
+
+	recordID, err := strconv.ParseInt(id[i+1:], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("expected domain_name/record_id, got: %q", id)
+	}
+	return id[:i], recordID, nil
+}
+
+// RecordResourceModelV0 is the state of fornex_record before schema version 1,
+// which stored the bare numeric record ID in id.
+type RecordResourceModelV0 struct {
+	ID         types.Int64  `tfsdk:"id"`
+	DomainName types.String `tfsdk:"domain_name"`
+	Host       types.String `tfsdk:"host"`
+	Type       types.String `tfsdk:"type"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+	Value      types.String `tfsdk:"value"`
+	Priority   types.Int64  `tfsdk:"priority"`
+
+	WaitForPropagation *RecordPropagationModelV0 `tfsdk:"wait_for_propagation"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// RecordPropagationModelV0 is the wait_for_propagation block of schema
+// version 0.
+type RecordPropagationModelV0 struct {
+	Timeout      types.String `tfsdk:"timeout"`
+	PollInterval types.String `tfsdk:"poll_interval"`
+	Nameservers  types.List   `tfsdk:"nameservers"`
+}
+
+func recordSchemaV0(ctx context.Context) *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":          schema.Int64Attribute{Computed: true},
+			"domain_name": schema.StringAttribute{Required: true},
+			"host":        schema.StringAttribute{Required: true},
+			"type":        schema.StringAttribute{Required: true},
+			"ttl":         schema.Int64Attribute{Optional: true, Computed: true},
+			"value":       schema.StringAttribute{Required: true},
+			"priority":    schema.Int64Attribute{Optional: true},
+		},
+		Blocks: map[string]schema.Block{
+			"wait_for_propagation": schema.SingleNestedBlock{
+				Attributes: map[string]schema.Attribute{
+					"timeout":       schema.StringAttribute{Optional: true},
+					"poll_interval": schema.StringAttribute{Optional: true},
+					"nameservers":   schema.ListAttribute{ElementType: types.StringType, Optional: true},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+func (r *RecordResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   recordSchemaV0(ctx),
+			StateUpgrader: upgradeRecordStateV0,
+		},
+	}
+}
+
+// upgradeRecordStateV0 moves the numeric id to record_id and replaces id with
+// the composite "domain_name/record_id" form.
+func upgradeRecordStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior RecordResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var propagation *RecordPropagationModel
+	if p := prior.WaitForPropagation; p != nil {
+		propagation = &RecordPropagationModel{
+			Timeout:       p.Timeout,
+			PollInterval:  p.PollInterval,
+			Nameservers:   p.Nameservers,
+			FailOnTimeout: types.BoolNull(),
+		}
+	}
+
+	upgraded := RecordResourceModel{
+		ID:                 types.StringValue(formatRecordID(prior.DomainName.ValueString(), prior.ID.ValueInt64())),
+		RecordID:           prior.ID,
+		DomainName:         prior.DomainName,
+		Host:               prior.Host,
+		Type:               prior.Type,
+		TTL:                prior.TTL,
+		Value:              prior.Value,
+		Priority:           prior.Priority,
+		WaitForPropagation: propagation,
+		Timeouts:           prior.Timeouts,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
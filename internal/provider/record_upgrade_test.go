@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRecordUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &RecordResource{}
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	if !ok {
+		t.Fatal("Expected a state upgrader for version 0")
+	}
+
+	// Raw v0 state as stored by earlier provider versions.
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx).(tftypes.Object)
+	values := map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.Number, 4242),
+		"domain_name": tftypes.NewValue(tftypes.String, "example.com"),
+		"host":        tftypes.NewValue(tftypes.String, "www"),
+		"type":        tftypes.NewValue(tftypes.String, "A"),
+		"ttl":         tftypes.NewValue(tftypes.Number, 300),
+		"value":       tftypes.NewValue(tftypes.String, "192.0.2.1"),
+	}
+	for name, typ := range priorType.AttributeTypes {
+		if _, ok := values[name]; !ok {
+			values[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+	prior := tfsdk.State{
+		Schema: upgrader.PriorSchema,
+		Raw:    tftypes.NewValue(priorType, values),
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	if schemaResp.Schema.Version != 1 {
+		t.Fatalf("Expected schema version 1, got: %d", schemaResp.Schema.Version)
+	}
+
+	resp := &resource.UpgradeStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Expected no error, got: %v", resp.Diagnostics)
+	}
+
+	var got RecordResourceModel
+	if diags := resp.State.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("Expected no error, got: %v", diags)
+	}
+
+	if got.ID.ValueString() != "example.com/4242" {
+		t.Errorf("Expected id example.com/4242, got: %s", got.ID)
+	}
+	if got.RecordID.ValueInt64() != 4242 {
+		t.Errorf("Expected record_id 4242, got: %s", got.RecordID)
+	}
+	if got.Host.ValueString() != "www" || got.TTL.ValueInt64() != 300 || !got.Priority.IsNull() {
+		t.Errorf("Expected other attributes to be kept, got: %+v", got)
+	}
+}
+
+func TestParseRecordID(t *testing.T) {
+	tests := []struct {
+		id     string
+		domain string
+		record int64
+	}{
+		{"example.com/42", "example.com", 42},
+		{"example.com:42", "example.com", 42},
+	}
+
+	for _, tt := range tests {
+		domain, record, err := parseRecordID(tt.id)
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %s", tt.id, err)
+		}
+		if domain != tt.domain || record != tt.record {
+			t.Errorf("%s: expected %s and %d, got %s and %d", tt.id, tt.domain, tt.record, domain, record)
+		}
+	}
+
+	for _, id := range []string{"example.com", "/42", "example.com/", "example.com/www"} {
+		if _, _, err := parseRecordID(id); err == nil {
+			t.Errorf("%s: expected error, got nil", id)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &RelativeHostFunction{}
+
+type RelativeHostFunction struct{}
+
+func NewRelativeHostFunction() function.Function {
+	return &RelativeHostFunction{}
+}
+
+func (f *RelativeHostFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "relative_host"
+}
+
+func (f *RelativeHostFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Strip the domain from a fully qualified name",
+		MarkdownDescription: "Returns the host part of `fqdn` relative to `domain`, as used by the `host` attribute of " +
+			"`fornex_record`. The domain apex is returned as `@`. Fails if `fqdn` is not within `domain`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "fqdn",
+				MarkdownDescription: "Fully qualified name, with or without the trailing dot, e.g. `www.example.com.`.",
+			},
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "Domain name, e.g. `example.com`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RelativeHostFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fqdn, domain string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &fqdn, &domain))
+	if resp.Error != nil {
+		return
+	}
+
+	host, err := relativeHost(fqdn, domain)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, host))
+}
+
+func relativeHost(fqdn, domain string) (string, error) {
+	name := strings.TrimSuffix(strings.TrimSpace(fqdn), ".")
+	domain = strings.TrimSuffix(strings.TrimSpace(domain), ".")
+	if domain == "" {
+		return "", fmt.Errorf("domain must not be empty")
+	}
+
+	if strings.EqualFold(name, domain) {
+		return "@", nil
+	}
+
+	suffix := "." + domain
+	if len(name) <= len(suffix) || !strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		return "", fmt.Errorf("%q is not within domain %q", fqdn, domain)
+	}
+	return name[:len(name)-len(suffix)], nil
+}
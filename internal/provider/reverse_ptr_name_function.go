@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/miekg/dns"
+)
+
+var _ function.Function = &ReversePTRNameFunction{}
+
+type ReversePTRNameFunction struct{}
+
+func NewReversePTRNameFunction() function.Function {
+	return &ReversePTRNameFunction{}
+}
+
+func (f *ReversePTRNameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "reverse_ptr_name"
+}
+
+func (f *ReversePTRNameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the reverse DNS name of an IP address",
+		MarkdownDescription: "Returns the dot-terminated PTR record name of an IPv4 or IPv6 address, " +
+			"e.g. `4.3.2.1.in-addr.arpa.` for `1.2.3.4`. IPv6 addresses use the nibble format under `ip6.arpa.`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "ip",
+				MarkdownDescription: "IPv4 or IPv6 address.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ReversePTRNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var ip string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &ip))
+	if resp.Error != nil {
+		return
+	}
+
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid IP address: "+err.Error())
+		return
+	}
+
+	name, err := dns.ReverseAddr(addr.Unmap().String())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, name))
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// maxTXTStringLength is the maximum length in bytes of a single character
+// string in a TXT record.
+const maxTXTStringLength = 255
+
+var _ function.Function = &SplitTXTFunction{}
+
+type SplitTXTFunction struct{}
+
+func NewSplitTXTFunction() function.Function {
+	return &SplitTXTFunction{}
+}
+
+func (f *SplitTXTFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "split_txt"
+}
+
+func (f *SplitTXTFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a long TXT value into character strings",
+		MarkdownDescription: "Splits `value` into chunks of at most 255 bytes, the limit of a single TXT character string, " +
+			"without breaking multi-byte characters. Useful for DKIM keys and other long TXT values.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "TXT record value.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *SplitTXTFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, splitTXT(value)))
+}
+
+func splitTXT(value string) []string {
+	chunks := []string{}
+	for len(value) > maxTXTStringLength {
+		end := maxTXTStringLength
+		for end > 0 && !utf8.RuneStart(value[end]) {
+			end--
+		}
+		chunks = append(chunks, value[:end])
+		value = value[end:]
+	}
+	return append(chunks, value)
+}
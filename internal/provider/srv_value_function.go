@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/miekg/dns"
+)
+
+var _ function.Function = &SRVValueFunction{}
+
+type SRVValueFunction struct{}
+
+func NewSRVValueFunction() function.Function {
+	return &SRVValueFunction{}
+}
+
+func (f *SRVValueFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "srv_value"
+}
+
+func (f *SRVValueFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compose the value of an SRV record",
+		MarkdownDescription: "Returns the `value` of an SRV `fornex_record`, `<weight> <port> <target>`, " +
+			"e.g. `10 5060 sip.example.com.`. The priority is set with the record `priority` attribute. " +
+			"The target is made fully qualified; use `.` to announce that the service is not available.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "weight",
+				MarkdownDescription: "Relative weight for records with the same priority, 0 to 65535.",
+			},
+			function.Int64Parameter{
+				Name:                "port",
+				MarkdownDescription: "Port of the service, 0 to 65535.",
+			},
+			function.StringParameter{
+				Name:                "target",
+				MarkdownDescription: "Host name providing the service.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SRVValueFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var weight, port int64
+	var target string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &weight, &port, &target))
+	if resp.Error != nil {
+		return
+	}
+
+	if weight < 0 || weight > 65535 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("weight must be between 0 and 65535, got: %d", weight))
+		return
+	}
+	if port < 0 || port > 65535 {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("port must be between 0 and 65535, got: %d", port))
+		return
+	}
+
+	target = strings.TrimSpace(target)
+	if target == "" {
+		resp.Error = function.NewArgumentFuncError(2, "target must not be empty")
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, fmt.Sprintf("%d %d %s", weight, port, dns.Fqdn(target))))
+}
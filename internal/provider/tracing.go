@@ -0,0 +1,26 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mglants/terraform-provider-fornex/internal/tracing"
+)
+
+// startSpan starts the parent span of a resource operation such as
+// "fornex_record.Create". API request spans started by the client with the
+// returned context are nested under it.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.Tracer(nil).Start(ctx, name)
+}
+
+// endSpan ends span, marking it failed if diags contains an error.
+func endSpan(span trace.Span, diags diag.Diagnostics) {
+	if errs := diags.Errors(); len(errs) > 0 {
+		span.SetStatus(codes.Error, errs[0].Summary()+": "+errs[0].Detail())
+	}
+	span.End()
+}
@@ -0,0 +1,113 @@
+package tfgen
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+// maxValueNameLength caps the part of a resource name derived from a record
+// value, so long TXT values do not produce unwieldy names.
+const maxValueNameLength = 32
+
+// Identifier turns s into a valid Terraform identifier: lower case letters,
+// digits and underscores, not starting with a digit.
+func Identifier(s string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if !underscore && b.Len() > 0 {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+
+	id := strings.TrimSuffix(b.String(), "_")
+	if id == "" {
+		return "_"
+	}
+	if id[0] >= '0' && id[0] <= '9' {
+		id = "_" + id
+	}
+	return id
+}
+
+// hostName returns the name part for a record host.
+func hostName(host, domain string) string {
+	host = strings.TrimSuffix(host, ".")
+	switch {
+	case host == "" || host == "@" || strings.EqualFold(host, domain):
+		return "apex"
+	case host == "*":
+		return "wildcard"
+	}
+	host = strings.TrimSuffix(host, "."+domain)
+	return strings.ReplaceAll(host, "*", "wildcard")
+}
+
+// RecordNames returns a stable name for every entry, relative to its domain.
+// Names are derived from host and type, and for every type but CNAME, which
+// allows a single record per host, from the value too, so adding or removing
+// a sibling record never renames existing ones. Values too long for a name
+// are shortened and suffixed with a hash of the full value. Only records with
+// the same name so derived, such as exact duplicates, are told apart by a
+// hash or their ID. The result does not depend on the order of entries.
+func RecordNames(domain string, entries []client.Entry) map[int]string {
+	sorted := append([]client.Entry(nil), entries...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Value != b.Value {
+			return a.Value < b.Value
+		}
+		return a.ID < b.ID
+	})
+
+	names := make(map[int]string, len(sorted))
+	used := map[string]bool{}
+	for _, e := range sorted {
+		name := recordName(domain, e)
+		if used[name] {
+			name = fmt.Sprintf("%s_%08x", name, hash(e.Value))
+		}
+		for used[name] {
+			name = fmt.Sprintf("%s_%d", name, e.ID)
+		}
+		used[name] = true
+		names[e.ID] = name
+	}
+	return names
+}
+
+// recordName derives the name of a record from the record alone.
+func recordName(domain string, e client.Entry) string {
+	name := Identifier(hostName(e.Host, domain) + "_" + e.Type)
+	if strings.EqualFold(e.Type, "CNAME") {
+		return name
+	}
+
+	value := strings.TrimPrefix(Identifier(e.Value), "_")
+	if len(value) > maxValueNameLength {
+		value = fmt.Sprintf("%s_%08x", strings.TrimSuffix(value[:maxValueNameLength], "_"), hash(e.Value))
+	}
+	return name + "_" + value
+}
+
+func hash(s string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum32()
+}
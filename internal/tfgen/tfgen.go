@@ -0,0 +1,220 @@
+// Package tfgen generates Terraform configuration, including import blocks,
+// for domains and records that already exist in a Fornex account.
+package tfgen
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+// Zone is a domain together with its records.
+type Zone struct {
+	Domain  client.Domain
+	Entries []client.Entry
+}
+
+// Options control the generated configuration.
+type Options struct {
+	// ForEach emits one fornex_record resource per domain with a for_each map
+	// of its records instead of one resource block per record.
+	ForEach bool
+	// Skipped, if set, receives one line for every record left out because
+	// fornex_record does not support its type, e.g. SOA.
+	Skipped io.Writer
+}
+
+// Generate writes fornex_domain and fornex_record resources with matching
+// import blocks for zones to w. Zones are written in order of domain name.
+// Records of types fornex_record does not support are left out.
+func Generate(w io.Writer, zones []Zone, opts Options) error {
+	zones = append([]Zone(nil), zones...)
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Domain.Name < zones[j].Domain.Name })
+
+	g := &generator{}
+	for _, zone := range zones {
+		domainName := Identifier(zone.Domain.Name)
+		g.domain(domainName, zone.Domain)
+
+		var entries []client.Entry
+		for _, e := range zone.Entries {
+			if !slices.Contains(client.RecordTypes, strings.ToUpper(e.Type)) {
+				if opts.Skipped != nil {
+					fmt.Fprintf(opts.Skipped, "skipping %s record %q (%d) of %s: type not supported by fornex_record\n",
+						e.Type, e.Host, e.ID, zone.Domain.Name)
+				}
+				continue
+			}
+			entries = append(entries, e)
+		}
+
+		names := RecordNames(zone.Domain.Name, entries)
+		sort.Slice(entries, func(i, j int) bool { return names[entries[i].ID] < names[entries[j].ID] })
+
+		if opts.ForEach {
+			g.recordMap(domainName, zone.Domain.Name, entries, names)
+		} else {
+			for _, e := range entries {
+				g.record(domainName+"_"+names[e.ID], domainName, zone.Domain.Name, e)
+			}
+		}
+	}
+
+	out := strings.TrimRight(g.String(), "\n")
+	if out == "" {
+		return nil
+	}
+	_, err := io.WriteString(w, out+"\n")
+	return err
+}
+
+type generator struct {
+	strings.Builder
+}
+
+type attribute struct {
+	name  string
+	value string
+}
+
+// block writes a block with aligned attributes, the way terraform fmt does.
+func (g *generator) block(indent, header string, attrs []attribute) {
+	width := 0
+	for _, a := range attrs {
+		width = max(width, len(a.name))
+	}
+
+	fmt.Fprintf(g, "%s%s {\n", indent, header)
+	for _, a := range attrs {
+		fmt.Fprintf(g, "%s  %-*s = %s\n", indent, width, a.name, a.value)
+	}
+	fmt.Fprintf(g, "%s}\n\n", indent)
+}
+
+func (g *generator) domain(name string, domain client.Domain) {
+	address := "fornex_domain." + name
+	g.block("", "import", []attribute{
+		{"to", address},
+		{"id", Quote(domain.Name)},
+	})
+
+	attrs := []attribute{{"name", Quote(domain.Name)}}
+	if len(domain.Tags) > 0 {
+		tags := append([]string(nil), domain.Tags...)
+		sort.Strings(tags)
+		attrs = append(attrs, attribute{"tags", quoteList(tags)})
+	}
+	g.block("", fmt.Sprintf("resource %q %q", "fornex_domain", name), attrs)
+}
+
+func (g *generator) record(name, domainResource, domainName string, e client.Entry) {
+	g.block("", "import", []attribute{
+		{"to", "fornex_record." + name},
+		{"id", Quote(recordID(domainName, e.ID))},
+	})
+
+	attrs := []attribute{
+		{"domain_name", "fornex_domain." + domainResource + ".name"},
+		{"host", Quote(e.Host)},
+		{"type", Quote(e.Type)},
+		{"value", Quote(e.Value)},
+	}
+	if e.TTL != nil {
+		attrs = append(attrs, attribute{"ttl", strconv.Itoa(*e.TTL)})
+	}
+	if e.Priority != nil {
+		attrs = append(attrs, attribute{"priority", strconv.Itoa(*e.Priority)})
+	}
+	g.block("", fmt.Sprintf("resource %q %q", "fornex_record", name), attrs)
+}
+
+func (g *generator) recordMap(name, domainName string, entries []client.Entry, names map[int]string) {
+	if len(entries) == 0 {
+		return
+	}
+
+	for _, e := range entries {
+		g.block("", "import", []attribute{
+			{"to", fmt.Sprintf("fornex_record.%s[%s]", name, Quote(names[e.ID]))},
+			{"id", Quote(recordID(domainName, e.ID))},
+		})
+	}
+
+	width := 0
+	for _, e := range entries {
+		width = max(width, len(Quote(names[e.ID])))
+	}
+
+	fmt.Fprintf(g, "resource %q %q {\n  for_each = {\n", "fornex_record", name)
+	for _, e := range entries {
+		attrs := []string{
+			"host = " + Quote(e.Host),
+			"type = " + Quote(e.Type),
+			"value = " + Quote(e.Value),
+			"ttl = " + optionalInt(e.TTL),
+			"priority = " + optionalInt(e.Priority),
+		}
+		fmt.Fprintf(g, "    %-*s = { %s }\n", width, Quote(names[e.ID]), strings.Join(attrs, ", "))
+	}
+	g.WriteString("  }\n\n")
+	g.WriteString("  domain_name = fornex_domain." + name + ".name\n")
+	g.WriteString("  host        = each.value.host\n")
+	g.WriteString("  type        = each.value.type\n")
+	g.WriteString("  value       = each.value.value\n")
+	g.WriteString("  ttl         = each.value.ttl\n")
+	g.WriteString("  priority    = each.value.priority\n")
+	g.WriteString("}\n\n")
+}
+
+func recordID(domainName string, id int) string {
+	return domainName + "/" + strconv.Itoa(id)
+}
+
+// Quote returns s as an HCL string literal, escaping template sequences.
+func Quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			b.WriteString(`\\`)
+		case '"':
+			b.WriteString(`\"`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '$', '%':
+			b.WriteByte(c)
+			if i+1 < len(s) && s[i+1] == '{' {
+				b.WriteByte(c)
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = Quote(v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func optionalInt(v *int) string {
+	if v == nil {
+		return "null"
+	}
+	return strconv.Itoa(*v)
+}
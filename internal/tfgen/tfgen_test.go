@@ -0,0 +1,133 @@
+package tfgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+var testZone = Zone{
+	Domain: client.Domain{Name: "example.com", Tags: []string{"prod", "team-web"}},
+	Entries: []client.Entry{
+		{ID: 12, Host: "www", Type: "A", Value: "192.0.2.1", TTL: intPtr(3600)},
+		{ID: 13, Host: "@", Type: "MX", Value: "mail.example.com", Priority: intPtr(10)},
+		{ID: 14, Host: "@", Type: "MX", Value: "backup.example.com", Priority: intPtr(20)},
+		{ID: 15, Host: "_dmarc", Type: "TXT", Value: `v=DMARC1; p=none; "${x}"`},
+		{ID: 16, Host: "@", Type: "SOA", Value: "ns1.fornex.com. hostmaster.fornex.com. 1 3600 600 86400 3600"},
+	},
+}
+
+func TestGenerate(t *testing.T) {
+	var b, skipped strings.Builder
+	if err := Generate(&b, []Zone{testZone}, Options{Skipped: &skipped}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	out := b.String()
+
+	// fornex_record does not accept SOA records.
+	if strings.Contains(out, "SOA") || !strings.Contains(skipped.String(), `SOA record "@" (16) of example.com`) {
+		t.Errorf("Expected the SOA record to be skipped, got:\n%s\nskipped:\n%s", out, skipped.String())
+	}
+
+	for _, want := range []string{
+		"import {\n  to = fornex_domain.example_com\n  id = \"example.com\"\n}\n",
+		"resource \"fornex_domain\" \"example_com\" {\n  name = \"example.com\"\n  tags = [\"prod\", \"team-web\"]\n}\n",
+		"import {\n  to = fornex_record.example_com_www_a_192_0_2_1\n  id = \"example.com/12\"\n}\n",
+		"resource \"fornex_record\" \"example_com_www_a_192_0_2_1\" {\n" +
+			"  domain_name = fornex_domain.example_com.name\n" +
+			"  host        = \"www\"\n" +
+			"  type        = \"A\"\n" +
+			"  value       = \"192.0.2.1\"\n" +
+			"  ttl         = 3600\n" +
+			"}\n",
+		"resource \"fornex_record\" \"example_com_apex_mx_mail_example_com\" {",
+		"resource \"fornex_record\" \"example_com_apex_mx_backup_example_com\" {",
+		`value       = "v=DMARC1; p=none; \"$${x}\""`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain:\n%s\ngot:\n%s", want, out)
+		}
+	}
+
+	if !strings.HasSuffix(out, "}\n") || strings.HasSuffix(out, "\n\n") {
+		t.Errorf("Expected output to end with a single newline")
+	}
+}
+
+func TestGenerateForEach(t *testing.T) {
+	var b strings.Builder
+	if err := Generate(&b, []Zone{testZone}, Options{ForEach: true}); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"import {\n  to = fornex_record.example_com[\"www_a_192_0_2_1\"]\n  id = \"example.com/12\"\n}\n",
+		"resource \"fornex_record\" \"example_com\" {\n  for_each = {\n",
+		"    \"www_a_192_0_2_1\"             = { host = \"www\", type = \"A\", value = \"192.0.2.1\", ttl = 3600, priority = null }\n",
+		"  priority    = each.value.priority\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain:\n%s\ngot:\n%s", want, out)
+		}
+	}
+}
+
+func TestRecordNamesStable(t *testing.T) {
+	entries := []client.Entry{
+		{ID: 1, Host: "*", Type: "A", Value: "192.0.2.1"},
+		{ID: 2, Host: "www", Type: "TXT", Value: strings.Repeat("a", 40) + "1"},
+		{ID: 3, Host: "www", Type: "TXT", Value: strings.Repeat("a", 40) + "2"},
+		{ID: 4, Host: "www", Type: "TXT", Value: strings.Repeat("a", 40) + "2"},
+	}
+	reversed := []client.Entry{entries[3], entries[2], entries[1], entries[0]}
+
+	names, again := RecordNames("example.com", entries), RecordNames("example.com", reversed)
+
+	seen := map[string]bool{}
+	for id, name := range names {
+		if again[id] != name {
+			t.Errorf("Expected name of %d to be independent of order, got %q and %q", id, name, again[id])
+		}
+		if seen[name] {
+			t.Errorf("Duplicate name %q", name)
+		}
+		seen[name] = true
+	}
+	if names[1] != "wildcard_a_192_0_2_1" {
+		t.Errorf("Expected wildcard_a_192_0_2_1, got: %s", names[1])
+	}
+}
+
+func TestRecordNamesIndependentOfSiblings(t *testing.T) {
+	www := client.Entry{ID: 1, Host: "www", Type: "A", Value: "192.0.2.1"}
+	alias := client.Entry{ID: 2, Host: "ftp", Type: "CNAME", Value: "www.example.com"}
+	alone := RecordNames("example.com", []client.Entry{www, alias})
+	withSibling := RecordNames("example.com", []client.Entry{www, alias, {ID: 3, Host: "www", Type: "A", Value: "192.0.2.2"}})
+
+	if alone[1] != "www_a_192_0_2_1" || withSibling[1] != alone[1] {
+		t.Errorf("Expected www_a_192_0_2_1 with and without a sibling record, got %q and %q", alone[1], withSibling[1])
+	}
+	if alone[2] != "ftp_cname" {
+		t.Errorf("Expected ftp_cname, got: %s", alone[2])
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"example.com":    "example_com",
+		"Sub-Domain.NET": "sub_domain_net",
+		"1.2.3.4":        "_1_2_3_4",
+		"--":             "_",
+	}
+	for in, want := range tests {
+		if got := Identifier(in); got != want {
+			t.Errorf("Identifier(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+// otelEnv are the variables Enabled looks at; an empty value counts as unset.
+var otelEnv = []string{"OTEL_SDK_DISABLED", "OTEL_TRACES_EXPORTER", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"}
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected bool
+	}{
+		{"unset", nil, false},
+		{"endpoint", map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}, true},
+		{"traces endpoint", map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://localhost:4318/v1/traces"}, true},
+		{"otlp exporter", map[string]string{"OTEL_TRACES_EXPORTER": "OTLP"}, true},
+		{"other exporter", map[string]string{"OTEL_TRACES_EXPORTER": "none", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}, false},
+		{"sdk disabled", map[string]string{"OTEL_SDK_DISABLED": "true", "OTEL_TRACES_EXPORTER": "otlp"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range otelEnv {
+				t.Setenv(name, tt.env[name])
+			}
+
+			if got := Enabled(); got != tt.expected {
+				t.Errorf("Expected %t, got: %t", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSetupDisabled(t *testing.T) {
+	for _, name := range otelEnv {
+		t.Setenv(name, "")
+	}
+
+	before := otel.GetTracerProvider()
+	shutdown, err := Setup(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if otel.GetTracerProvider() != before {
+		t.Error("Expected the global tracer provider to be left alone")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Expected no error, got: %s", err)
+	}
+}
@@ -0,0 +1,111 @@
+package zonefile
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+// Change operations.
+const (
+	OpAdd    = "add"
+	OpDelete = "delete"
+	OpUpdate = "update"
+)
+
+// Change is a difference between the live zone and a zone file.
+type Change struct {
+	Op string `json:"op"`
+	// Live is the existing entry, nil for additions.
+	Live *client.Entry `json:"live,omitempty"`
+	// Desired is the entry from the zone file, nil for deletions.
+	Desired *client.Entry `json:"desired,omitempty"`
+}
+
+// Diff returns the changes that turn live into desired. Records are matched
+// by host, type, value and priority; a matching record with another TTL is an
+// update, anything else is deleted and added. Live entries without a TTL are
+// taken to have DefaultTTL, as in Export. Live entries of unsupported types,
+// such as SOA, are left alone.
+func Diff(domain string, live, desired []client.Entry) []Change {
+	live = Supported(live)
+
+	// Indices of the live entries not matched yet, by key.
+	remaining := map[string][]int{}
+	for i, e := range live {
+		k := key(domain, e)
+		remaining[k] = append(remaining[k], i)
+	}
+	matched := make([]bool, len(live))
+
+	var changes []Change
+	for _, d := range desired {
+		k := key(domain, d)
+		if len(remaining[k]) == 0 {
+			changes = append(changes, Change{Op: OpAdd, Desired: &d})
+			continue
+		}
+
+		i := remaining[k][0]
+		remaining[k] = remaining[k][1:]
+		matched[i] = true
+
+		l := live[i]
+		if d.TTL != nil && ttl(l) != *d.TTL {
+			d.ID = l.ID
+			changes = append(changes, Change{Op: OpUpdate, Live: &l, Desired: &d})
+		}
+	}
+
+	for i, l := range live {
+		if !matched[i] {
+			changes = append(changes, Change{Op: OpDelete, Live: &l})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return sortKey(domain, changes[i]) < sortKey(domain, changes[j])
+	})
+	return changes
+}
+
+func ttl(e client.Entry) int {
+	if e.TTL == nil {
+		return DefaultTTL
+	}
+	return *e.TTL
+}
+
+// key identifies a record independently of its ID and TTL.
+func key(domain string, e client.Entry) string {
+	priority := ""
+	if e.Priority != nil && (strings.EqualFold(e.Type, "MX") || strings.EqualFold(e.Type, "SRV")) {
+		priority = strconv.Itoa(*e.Priority)
+	}
+	return strings.Join([]string{client.CanonicalHost(e.Host, domain), strings.ToUpper(e.Type), normalizeValue(e.Type, e.Value), priority}, "\x00")
+}
+
+func sortKey(domain string, c Change) string {
+	e := c.Desired
+	if e == nil {
+		e = c.Live
+	}
+	return client.CanonicalHost(e.Host, domain) + "\x00" + strings.ToUpper(e.Type) + "\x00" + e.Value
+}
+
+// normalizeValue ignores case and trailing dots of names, but not the
+// contents of TXT records.
+func normalizeValue(typ, value string) string {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(typ, "TXT") {
+		return strings.Trim(value, `"`)
+	}
+
+	fields := strings.Fields(strings.ToLower(value))
+	for i, f := range fields {
+		fields[i] = strings.TrimSuffix(f, ".")
+	}
+	return strings.Join(fields, " ")
+}
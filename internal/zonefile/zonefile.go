@@ -0,0 +1,240 @@
+// Package zonefile converts between Fornex DNS entries and RFC 1035 zone
+// files, and computes the changes needed to make a live zone match a file.
+package zonefile
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+	"github.com/miekg/dns"
+)
+
+// DefaultTTL is written for entries without a TTL and assumed for zone file
+// records without one.
+const DefaultTTL = 3600
+
+// maxTXTStringLength is the maximum length of a single TXT character string.
+const maxTXTStringLength = 255
+
+// Export writes entries as a zone file for domain. SOA records and other
+// types Fornex does not manage through the API are skipped.
+func Export(w io.Writer, domain string, entries []client.Entry) error {
+	origin := dns.Fqdn(domain)
+
+	sorted := Supported(entries)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if client.CanonicalHost(sorted[i].Host, domain) != client.CanonicalHost(sorted[j].Host, domain) {
+			return client.CanonicalHost(sorted[i].Host, domain) < client.CanonicalHost(sorted[j].Host, domain)
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+
+	if _, err := fmt.Fprintf(w, "$ORIGIN %s\n$TTL %d\n", origin, DefaultTTL); err != nil {
+		return err
+	}
+	for _, e := range sorted {
+		rr, err := ToRR(domain, e)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w, rr.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToRR converts an entry to a resource record. Names in CNAME, MX, NS and SRV
+// values are taken as fully qualified.
+func ToRR(domain string, e client.Entry) (dns.RR, error) {
+	ttl := DefaultTTL
+	if e.TTL != nil {
+		ttl = *e.TTL
+	}
+	priority := 0
+	if e.Priority != nil {
+		priority = *e.Priority
+	}
+
+	var data string
+	switch strings.ToUpper(e.Type) {
+	case "CNAME", "NS":
+		data = dns.Fqdn(e.Value)
+	case "MX":
+		data = fmt.Sprintf("%d %s", priority, dns.Fqdn(e.Value))
+	case "SRV":
+		fields := strings.Fields(e.Value)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("record %d: expected SRV value \"weight port target\", got: %q", e.ID, e.Value)
+		}
+		data = fmt.Sprintf("%d %s %s %s", priority, fields[0], fields[1], dns.Fqdn(fields[2]))
+	case "TXT":
+		data = quoteTXT(e.Value)
+	default:
+		data = e.Value
+	}
+
+	name := fqdn(e.Host, domain)
+	rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", name, ttl, strings.ToUpper(e.Type), data))
+	if err != nil {
+		return nil, fmt.Errorf("record %d (%s %s): %w", e.ID, e.Host, e.Type, err)
+	}
+	return rr, nil
+}
+
+// Parse reads a zone file for domain and returns its records as entries.
+// Relative names are resolved against domain. SOA records are skipped.
+func Parse(r io.Reader, domain string) ([]client.Entry, error) {
+	origin := dns.Fqdn(domain)
+	zp := dns.NewZoneParser(r, origin, "")
+	zp.SetDefaultTTL(DefaultTTL)
+
+	var entries []client.Entry
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		if _, isSOA := rr.(*dns.SOA); isSOA {
+			continue
+		}
+
+		e, err := FromRR(domain, rr)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err := zp.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FromRR converts a resource record of domain to an entry.
+func FromRR(domain string, rr dns.RR) (client.Entry, error) {
+	hdr := rr.Header()
+	if !dns.IsSubDomain(dns.Fqdn(domain), hdr.Name) {
+		return client.Entry{}, fmt.Errorf("%s is not within %s", hdr.Name, domain)
+	}
+
+	ttl := int(hdr.Ttl)
+	e := client.Entry{
+		Host: client.CanonicalHost(hdr.Name, domain),
+		Type: dns.TypeToString[hdr.Rrtype],
+		TTL:  &ttl,
+	}
+
+	switch v := rr.(type) {
+	case *dns.A:
+		e.Value = v.A.String()
+	case *dns.AAAA:
+		e.Value = v.AAAA.String()
+	case *dns.CNAME:
+		e.Value = strings.TrimSuffix(v.Target, ".")
+	case *dns.NS:
+		e.Value = strings.TrimSuffix(v.Ns, ".")
+	case *dns.MX:
+		priority := int(v.Preference)
+		e.Priority = &priority
+		e.Value = strings.TrimSuffix(v.Mx, ".")
+	case *dns.SRV:
+		priority := int(v.Priority)
+		e.Priority = &priority
+		e.Value = fmt.Sprintf("%d %d %s", v.Weight, v.Port, strings.TrimSuffix(v.Target, "."))
+	case *dns.TXT:
+		// The parser keeps character strings in their escaped form.
+		e.Value = unescape(strings.Join(v.Txt, ""))
+	case *dns.CAA:
+		e.Value = fmt.Sprintf("%d %s %q", v.Flag, v.Tag, v.Value)
+	default:
+		return client.Entry{}, fmt.Errorf("%s: record type %s is not supported by Fornex", hdr.Name, e.Type)
+	}
+	return e, nil
+}
+
+// Supported returns the entries of a type in client.RecordTypes. The API
+// lists the SOA record with the others, but it cannot be changed.
+func Supported(entries []client.Entry) []client.Entry {
+	var supported []client.Entry
+	for _, e := range entries {
+		if slices.Contains(client.RecordTypes, strings.ToUpper(e.Type)) {
+			supported = append(supported, e)
+		}
+	}
+	return supported
+}
+
+func fqdn(host, domain string) string {
+	if h := client.CanonicalHost(host, domain); h != "@" {
+		return h + "." + dns.Fqdn(domain)
+	}
+	return dns.Fqdn(domain)
+}
+
+// quoteTXT quotes a TXT value, split into character strings of at most 255
+// bytes.
+func quoteTXT(value string) string {
+	var parts []string
+	for {
+		chunk := value
+		if len(chunk) > maxTXTStringLength {
+			chunk = chunk[:maxTXTStringLength]
+		}
+		parts = append(parts, quote(chunk))
+		value = value[len(chunk):]
+		if value == "" {
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// quote returns s as a zone file character string. Non-printable bytes are
+// written as \DDD escapes.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c > '~':
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// unescape resolves the \X and \DDD escapes of a character string.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+3 < len(s) && isDigit(s[i+1]) && isDigit(s[i+2]) && isDigit(s[i+3]) {
+			n, _ := strconv.Atoi(s[i+1 : i+4])
+			b.WriteByte(byte(n))
+			i += 3
+			continue
+		}
+		i++
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
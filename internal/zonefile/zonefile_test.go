@@ -0,0 +1,131 @@
+package zonefile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mglants/terraform-provider-fornex/internal/client"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+const testZone = `$ORIGIN example.com.
+$TTL 600
+@        IN SOA ns1.fornex.com. hostmaster.example.com. 1 7200 3600 1209600 3600
+@        IN NS  ns1.fornex.com.
+@     300 IN MX 10 mail
+www       IN A   192.0.2.1
+_sip._tcp IN SRV 10 60 5060 sip.example.com.
+_dmarc    IN TXT "v=DMARC1; " "p=none"
+blog.example.com. IN CNAME ghs.example.net.
+`
+
+func TestParse(t *testing.T) {
+	entries, err := Parse(strings.NewReader(testZone), "example.com")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if len(entries) != 6 {
+		t.Fatalf("Expected 6 entries without the SOA, got: %d", len(entries))
+	}
+
+	mx := entries[1]
+	if mx.Host != "@" || mx.Type != "MX" || mx.Value != "mail.example.com" || *mx.Priority != 10 || *mx.TTL != 300 {
+		t.Errorf("Unexpected MX entry: %+v", mx)
+	}
+	if www := entries[2]; www.Host != "www" || www.Value != "192.0.2.1" || *www.TTL != 600 {
+		t.Errorf("Unexpected A entry: %+v", www)
+	}
+	if srv := entries[3]; srv.Host != "_sip._tcp" || srv.Value != "60 5060 sip.example.com" || *srv.Priority != 10 {
+		t.Errorf("Unexpected SRV entry: %+v", srv)
+	}
+	if txt := entries[4]; txt.Value != "v=DMARC1; p=none" {
+		t.Errorf("Unexpected TXT entry: %+v", txt)
+	}
+	if cname := entries[5]; cname.Host != "blog" || cname.Value != "ghs.example.net" {
+		t.Errorf("Unexpected CNAME entry: %+v", cname)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, zone := range []string{
+		"www.example.net. 300 IN A 192.0.2.1\n",
+		"www 300 IN PTR host.example.com.\n",
+		"www 300 IN A not-an-ip\n",
+	} {
+		if _, err := Parse(strings.NewReader(zone), "example.com"); err == nil {
+			t.Errorf("Expected error for %q, got nil", zone)
+		}
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	entries := []client.Entry{
+		{ID: 1, Host: "www", Type: "A", Value: "192.0.2.1", TTL: intPtr(300)},
+		{ID: 2, Host: "@", Type: "MX", Value: "mail.example.com", Priority: intPtr(10)},
+		{ID: 3, Host: "_sip._tcp", Type: "SRV", Value: "60 5060 sip.example.com", TTL: intPtr(300), Priority: intPtr(10)},
+		{ID: 4, Host: "dkim._domainkey", Type: "TXT", Value: strings.Repeat("k", 300) + ` "quoted" \ é`, TTL: intPtr(300)},
+		{ID: 5, Host: "@", Type: "CAA", Value: `0 issue "letsencrypt.org"`, TTL: intPtr(300)},
+		{ID: 6, Host: "example.com", Type: "SOA", Value: "ns1.fornex.com. hostmaster.fornex.com. 1 7200 3600 1209600 3600"},
+	}
+
+	var b strings.Builder
+	if err := Export(&b, "example.com", entries); err != nil {
+		t.Fatalf("Expected no error, got: %s", err)
+	}
+	if strings.Contains(b.String(), "SOA") {
+		t.Errorf("Expected the SOA record to be skipped, got:\n%s", b.String())
+	}
+
+	parsed, err := Parse(strings.NewReader(b.String()), "example.com")
+	if err != nil {
+		t.Fatalf("Expected exported zone to parse, got: %s\n%s", err, b.String())
+	}
+
+	// The MX record has no TTL and is exported with the default TTL, which
+	// is not a change either.
+	if changes := Diff("example.com", entries, parsed); len(changes) != 0 {
+		t.Errorf("Expected no changes after a round trip, got: %+v\n%s", changes, b.String())
+	}
+}
+
+func TestDiff(t *testing.T) {
+	live := []client.Entry{
+		{ID: 1, Host: "www", Type: "A", Value: "192.0.2.1", TTL: intPtr(300)},
+		{ID: 2, Host: "old", Type: "A", Value: "192.0.2.2", TTL: intPtr(300)},
+		{ID: 3, Host: "@", Type: "MX", Value: "mail.example.com.", TTL: intPtr(300), Priority: intPtr(10)},
+		// Fornex manages the SOA record, so it is never deleted.
+		{ID: 4, Host: "@", Type: "SOA", Value: "ns1.fornex.com. hostmaster.fornex.com. 1 7200 3600 1209600 3600"},
+		{ID: 5, Host: "ptr", Type: "PTR", Value: "host.example.com"},
+	}
+	desired := []client.Entry{
+		{Host: "WWW", Type: "A", Value: "192.0.2.1", TTL: intPtr(600)},
+		{Host: "new", Type: "A", Value: "192.0.2.3", TTL: intPtr(300)},
+		{Host: "@", Type: "MX", Value: "mail.example.com", TTL: intPtr(300), Priority: intPtr(10)},
+	}
+
+	changes := Diff("example.com", live, desired)
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got: %+v", changes)
+	}
+
+	ops := map[string]string{}
+	for _, c := range changes {
+		switch c.Op {
+		case OpAdd:
+			ops[c.Desired.Host] = c.Op
+		case OpDelete:
+			ops[c.Live.Host] = c.Op
+		case OpUpdate:
+			ops[c.Live.Host] = c.Op
+			if c.Desired.ID != c.Live.ID {
+				t.Errorf("Expected update to carry the live ID, got: %d", c.Desired.ID)
+			}
+		}
+	}
+	if ops["new"] != OpAdd || ops["old"] != OpDelete || ops["www"] != OpUpdate {
+		t.Errorf("Unexpected changes: %v", ops)
+	}
+}